@@ -1,11 +1,15 @@
 package app
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"net/http"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,7 +17,10 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/gitrepo"
 	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/theme"
+	"github.com/ayoisaiah/focus/internal/timeutil"
 	"github.com/ayoisaiah/focus/stats"
 	"github.com/ayoisaiah/focus/store"
 	"github.com/ayoisaiah/focus/timer"
@@ -37,6 +44,21 @@ func firstNonEmptyString(ss ...string) string {
 	return ""
 }
 
+// confirm asks a yes or no question and reports whether it was answered with
+// yes. Anything other than `y` or `yes` is taken as no.
+func confirm(question string) bool {
+	fmt.Fprint(os.Stdout, pterm.Warning.Sprint(question+" [y/N] "))
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+
+	return false
+}
+
 // checkForUpdates alerts the user if there is
 // an updated version of Focus from the one currently installed.
 func checkForUpdates(app *cli.App) {
@@ -123,14 +145,352 @@ func editConfigAction(ctx *cli.Context) error {
 	return nil
 }
 
-// statsAction launches the statistics server
+// statsAction launches the statistics server. The statistics include the
+// commits in the repository specified with --repo, if any.
 func statsAction(ctx *cli.Context) error {
+	var commits *stats.CommitOptions
+
+	if path := ctx.String("repo"); path != "" {
+		repo, err := gitrepo.Find(path)
+		if err != nil {
+			return errNotGitRepo.Fmt(path, err)
+		}
+
+		commits = &stats.CommitOptions{Repo: repo}
+	}
+
+	cfg, err := config.New(config.WithViperConfig(config.ConfigFilePath()))
+	if err != nil {
+		return err
+	}
+
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	return stats.Server(db, cfg, ctx.Uint("port"), commits)
+}
+
+// reportAction generates a static statistics report for the specified period.
+func reportAction(ctx *cli.Context) error {
+	if !ctx.IsSet("period") && !ctx.IsSet("start") {
+		err := ctx.Set("period", string(timeutil.Period7Days))
+		if err != nil {
+			return err
+		}
+	}
+
+	filter := config.Filter(ctx)
+
+	cfg, err := config.New(config.WithViperConfig(config.ConfigFilePath()))
+	if err != nil {
+		return err
+	}
+
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	s := &stats.Stats{
+		DB:          db,
+		StartTime:   filter.StartTime,
+		EndTime:     filter.EndTime,
+		Tags:        filter.Tags,
+		GoalsConfig: cfg.Goals,
+	}
+
+	err = s.Compute()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	err = s.WriteReport(&buf, stats.ReportFormat(ctx.String("format")))
+	if err != nil {
+		return err
+	}
+
+	if path := ctx.String("out"); path != "" {
+		return os.WriteFile(path, buf.Bytes(), 0o644)
+	}
+
+	_, err = buf.WriteTo(os.Stdout)
+
+	return err
+}
+
+// statsCommitsAction correlates the commits in a git repository with the
+// work sessions in the specified period.
+func statsCommitsAction(ctx *cli.Context) error {
+	if !ctx.IsSet("period") && !ctx.IsSet("start") {
+		err := ctx.Set("period", string(timeutil.Period7Days))
+		if err != nil {
+			return err
+		}
+	}
+
+	filter := config.Filter(ctx)
+
+	repo, err := gitrepo.Find(ctx.String("repo"))
+	if err != nil {
+		return errNotGitRepo.Fmt(ctx.String("repo"), err)
+	}
+
+	cfg, err := config.New(config.WithViperConfig(config.ConfigFilePath()))
+	if err != nil {
+		return err
+	}
+
 	db, err := store.NewClient(config.DBFilePath())
 	if err != nil {
 		return err
 	}
 
-	return stats.Server(db, ctx.Uint("port"))
+	s := &stats.Stats{
+		DB:          db,
+		StartTime:   filter.StartTime,
+		EndTime:     filter.EndTime,
+		Tags:        filter.Tags,
+		GoalsConfig: cfg.Goals,
+		CommitOptions: &stats.CommitOptions{
+			Repo:   repo,
+			Author: ctx.String("author"),
+		},
+	}
+
+	err = s.Compute()
+	if err != nil {
+		db.Close()
+
+		return err
+	}
+
+	// the database is released before the git history is walked so that a
+	// running timer can save its sessions in the meantime
+	err = db.Close()
+	if err != nil {
+		return err
+	}
+
+	err = s.ComputeCommits()
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool("json") {
+		b, err := s.ToJSON()
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintln(os.Stdout, string(b))
+
+		return err
+	}
+
+	printCommits(os.Stdout, s.Commits)
+
+	return nil
+}
+
+// listAction handles the list command which prints a table of the sessions in
+// the specified period.
+func listAction(ctx *cli.Context) error {
+	if !ctx.IsSet("period") && !ctx.IsSet("start") {
+		err := ctx.Set("period", string(timeutil.Period7Days))
+		if err != nil {
+			return err
+		}
+	}
+
+	sessions, db, err := sessionHelper(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if pattern := ctx.String("grep"); pattern != "" {
+		sessions, err = grepSessions(sessions, pattern)
+		if err != nil {
+			return err
+		}
+	}
+
+	return listSessions(sessions)
+}
+
+// taskAddAction handles the task add command.
+func taskAddAction(ctx *cli.Context) error {
+	title := strings.TrimSpace(strings.Join(ctx.Args().Slice(), " "))
+	if title == "" {
+		return errTaskTitleArgs
+	}
+
+	var tags []string
+
+	if ctx.String("tag") != "" {
+		for _, tag := range strings.Split(ctx.String("tag"), ",") {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
+	}
+
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return addTask(db, title, int(ctx.Uint("est")), tags)
+}
+
+// taskListAction handles the task list command.
+func taskListAction(ctx *cli.Context) error {
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return listTasks(db, ctx.Bool("all"))
+}
+
+// taskDoneAction handles the task done command.
+func taskDoneAction(ctx *cli.Context) error {
+	id, err := strconv.Atoi(ctx.Args().First())
+	if ctx.NArg() != 1 || err != nil {
+		return errTaskIDArgs
+	}
+
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	return completeTask(db, id)
+}
+
+// allSessions returns every saved session along with the database client.
+// The connection is closed once the sessions are read so that a running timer
+// can save its sessions while the user is asked to confirm any changes. Use
+// store.Use to reopen it.
+func allSessions() ([]*models.Session, store.DB, error) {
+	db, err := store.NewClient(config.DBFilePath())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sessions, err := db.GetSessions(time.Time{}, time.Now(), nil)
+
+	cerr := db.Close()
+	if err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sessions, db, nil
+}
+
+// updateSessions reads the sessions that start at the specified times again
+// and saves them after applying update. Both happen while the database is held,
+// so anything a running timer saved to the sessions after they were first read
+// is kept. Sessions that no longer exist are skipped.
+func updateSessions(
+	db store.DB,
+	startTimes []time.Time,
+	update func(sess *models.Session),
+) error {
+	keys := make(map[int64]bool, len(startTimes))
+
+	for _, t := range startTimes {
+		keys[t.Unix()] = true
+	}
+
+	return store.Use(db, func() error {
+		sessions, err := db.GetSessions(time.Time{}, time.Now(), nil)
+		if err != nil {
+			return err
+		}
+
+		m := make(map[time.Time]*models.Session)
+
+		for _, sess := range sessions {
+			if !keys[sess.StartTime.Unix()] {
+				continue
+			}
+
+			update(sess)
+
+			m[sess.StartTime] = sess
+		}
+
+		return db.UpdateSessions(m)
+	})
+}
+
+// tagsListAction handles the tags list command which prints every tag along
+// with its usage.
+func tagsListAction(_ *cli.Context) error {
+	sessions, _, err := allSessions()
+	if err != nil {
+		return err
+	}
+
+	return listTags(sessions)
+}
+
+// tagsRenameAction handles the tags rename command.
+func tagsRenameAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errRenameTagArgs
+	}
+
+	sessions, db, err := allSessions()
+	if err != nil {
+		return err
+	}
+
+	return renameTag(db, sessions, ctx.Args().Get(0), ctx.Args().Get(1))
+}
+
+// tagsMergeAction handles the tags merge command.
+func tagsMergeAction(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return errMergeTagArgs
+	}
+
+	sessions, db, err := allSessions()
+	if err != nil {
+		return err
+	}
+
+	return mergeTags(db, sessions, ctx.Args().Slice(), ctx.String("into"))
+}
+
+// tagsDeleteAction handles the tags delete command.
+func tagsDeleteAction(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errDeleteTagArgs
+	}
+
+	sessions, db, err := allSessions()
+	if err != nil {
+		return err
+	}
+
+	return deleteTag(db, sessions, ctx.Args().First())
 }
 
 // statusAction handles the status command and prints the status of the currently
@@ -165,11 +525,22 @@ func defaultAction(ctx *cli.Context) error {
 		return err
 	}
 
+	defer t.Close()
+
+	if cfg.CLI.Accessible {
+		disableStyling()
+
+		return t.RunAccessible(os.Stdin)
+	}
+
 	p := tea.NewProgram(t)
 
 	_, err = p.Run()
+	if err != nil {
+		return err
+	}
 
-	return err
+	return t.Flush()
 }
 
 func beforeAction(ctx *cli.Context) error {
@@ -210,5 +581,20 @@ func beforeAction(ctx *cli.Context) error {
 		disableStyling()
 	}
 
+	// A broken theme should not prevent commands such as edit-config from
+	// running, so the default theme is used instead. The warning goes to
+	// stderr to keep the output of commands like status clean.
+	palette, err := config.LoadTheme(config.ConfigFilePath())
+	if err != nil {
+		pterm.Fprintln(
+			os.Stderr,
+			pterm.Warning.Sprintf("Using the default theme: %v", err),
+		)
+
+		return nil
+	}
+
+	theme.Set(palette)
+
 	return nil
 }
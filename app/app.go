@@ -48,7 +48,103 @@ func Get() *cli.App {
 				Track your progress with detailed statistics reporting. Defaults to a 
 				reporting period of 7 days`,
 				Action: statsAction,
-				Flags:  []cli.Flag{statsPortFlag},
+				Flags:  []cli.Flag{statsPortFlag, statsRepoFlag},
+				Subcommands: []*cli.Command{
+					{
+						Name:   "commits",
+						Usage:  "Match the commits in a git repository to the work sessions they were made in",
+						Action: statsCommitsAction,
+						Flags: []cli.Flag{
+							commitsRepoFlag,
+							commitsAuthorFlag,
+							periodFlag,
+							startFlag,
+							endFlag,
+							filterTagFlag,
+							commitsJSONFlag,
+						},
+					},
+				},
+			},
+			{
+				Name:   "report",
+				Usage:  "Generate a self-contained HTML or Markdown report of your statistics",
+				Action: reportAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					reportFormatFlag,
+					reportOutFlag,
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List your work sessions",
+				Action: listAction,
+				Flags: []cli.Flag{
+					periodFlag,
+					startFlag,
+					endFlag,
+					filterTagFlag,
+					grepFlag,
+				},
+			},
+			{
+				Name:  "task",
+				Usage: "Plan tasks and track estimated versus actual pomodoros",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "add",
+						Usage:     "Add a task",
+						ArgsUsage: "<title>",
+						Action:    taskAddAction,
+						Flags:     []cli.Flag{taskEstimateFlag, taskTagFlag},
+					},
+					{
+						Name:   "list",
+						Usage:  "List open tasks along with their estimated and actual pomodoros",
+						Action: taskListAction,
+						Flags:  []cli.Flag{taskAllFlag},
+					},
+					{
+						Name:      "done",
+						Usage:     "Mark a task as done",
+						ArgsUsage: "<id>",
+						Action:    taskDoneAction,
+					},
+				},
+			},
+			{
+				Name:  "tags",
+				Usage: "Manage the tags applied to your sessions",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "list",
+						Usage:  "List all tags along with their usage counts and total time",
+						Action: tagsListAction,
+					},
+					{
+						Name:      "rename",
+						Usage:     "Rename a tag across all sessions",
+						ArgsUsage: "<old> <new>",
+						Action:    tagsRenameAction,
+					},
+					{
+						Name:      "merge",
+						Usage:     "Merge one or more tags into another",
+						ArgsUsage: "<tag>... --into <tag>",
+						Action:    tagsMergeAction,
+						Flags:     []cli.Flag{tagsIntoFlag},
+					},
+					{
+						Name:      "delete",
+						Usage:     "Remove a tag from all sessions",
+						ArgsUsage: "<tag>",
+						Action:    tagsDeleteAction,
+					},
+				},
 			},
 			{
 				Name:   "status",
@@ -62,6 +158,8 @@ func Get() *cli.App {
 			longBreakIntervalFlag,
 			workFlag,
 			sinceFlag,
+			atFlag,
+			untilFlag,
 			disableNotificationFlag,
 			soundFlag,
 			soundOnBreakFlag,
@@ -69,6 +167,13 @@ func Get() *cli.App {
 			breakSoundFlag,
 			sessionCmdFlag,
 			addTagFlag,
+			taskFlag,
+			pickTaskFlag,
+			flowFlag,
+			presetFlag,
+			maxSessionsFlag,
+			accessibleFlag,
+			repoFlag,
 			strictFlag,
 			noColorFlag,
 		},
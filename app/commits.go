@@ -0,0 +1,57 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/ui"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+// printCommits prints the number of commits made during work sessions overall
+// and for each tag, followed by a table of the commits in the period.
+func printCommits(w io.Writer, c *stats.Commits) {
+	if c.Total == 0 {
+		pterm.Info.Printfln("No commits found in %s for the specified time range", c.Repo)
+		return
+	}
+
+	fmt.Fprintf(
+		w,
+		"%s: %s commits, %s made during %s pomodoros (%s per pomodoro)\n\n",
+		c.Repo,
+		ui.Highlight(c.Total),
+		ui.Highlight(c.InSessions),
+		ui.Highlight(c.Pomodoros),
+		ui.Success(strconv.FormatFloat(c.PerPomodoro, 'f', 2, 64)),
+	)
+
+	tableBody := [][]string{{"TAG", "POMODOROS", "COMMITS", "PER POMODORO"}}
+
+	for _, tag := range c.Tags {
+		tableBody = append(tableBody, []string{
+			tag.Name,
+			strconv.Itoa(tag.Pomodoros),
+			strconv.Itoa(tag.Commits),
+			strconv.FormatFloat(tag.PerPomodoro, 'f', 2, 64),
+		})
+	}
+
+	ui.PrintTable(tableBody, w)
+
+	tableBody = [][]string{{"DATE", "COMMIT", "AUTHOR", "SUBJECT"}}
+
+	for _, commit := range c.Commits {
+		tableBody = append(tableBody, []string{
+			commit.AuthorTime.Format("Jan 02, 2006 03:04 PM"),
+			commit.Hash[:7],
+			commit.Author,
+			commit.Subject,
+		})
+	}
+
+	ui.PrintTable(tableBody, w)
+}
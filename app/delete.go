@@ -1,7 +1,6 @@
 package app
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"time"
@@ -12,8 +11,10 @@ import (
 	"github.com/ayoisaiah/focus/store"
 )
 
-// delSessions deletes all the specified sessions. It requests for confirmation
-// before proceeding with the operation.
+// delSessions deletes all the specified sessions. It asks for confirmation
+// before proceeding with the operation, and only opens the database to delete
+// the sessions. Sessions are deleted by their start time, so nothing that was
+// saved to them in the meantime is written back.
 func delSessions(
 	db store.DB,
 	sessions []*models.Session,
@@ -30,15 +31,14 @@ func delSessions(
 
 	printSessionsTable(os.Stdout, sessions)
 
-	warning := pterm.Warning.Sprint(
-		"The above sessions will be deleted permanently. Press ENTER to proceed",
-	)
-
-	fmt.Fprint(os.Stdout, warning)
-
-	reader := bufio.NewReader(os.Stdin)
-
-	_, _ = reader.ReadString('\n')
+	if !confirm(
+		fmt.Sprintf("Delete the %d sessions above permanently?", len(sessions)),
+	) {
+		pterm.Info.Println(noChangesMsg)
+		return nil
+	}
 
-	return db.DeleteSessions(t)
+	return store.Use(db, func() error {
+		return db.DeleteSessions(t)
+	})
 }
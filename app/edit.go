@@ -1,7 +1,6 @@
 package app
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"time"
@@ -12,7 +11,9 @@ import (
 	"github.com/ayoisaiah/focus/store"
 )
 
-// editTags.edits the tags of the specified sessions.
+// editTags edits the tags of the specified sessions once the changes are
+// confirmed. The database is only opened to save the changes, and only the
+// tags of the sessions are changed.
 func editTags(
 	db store.DB,
 	sessions []*models.Session,
@@ -23,25 +24,22 @@ func editTags(
 		return nil
 	}
 
-	m := make(map[time.Time]*models.Session)
+	startTimes := make([]time.Time, len(sessions))
 
 	for i := range sessions {
 		sessions[i].Tags = args
 
-		m[sessions[i].StartTime] = sessions[i]
+		startTimes[i] = sessions[i].StartTime
 	}
 
 	printSessionsTable(os.Stdout, sessions)
 
-	warning := pterm.Warning.Sprint(
-		"The sessions above will be updated. Press ENTER to proceed",
-	)
-
-	fmt.Fprint(os.Stdout, warning)
-
-	reader := bufio.NewReader(os.Stdin)
-
-	_, _ = reader.ReadString('\n')
+	if !confirm(fmt.Sprintf("Update the %d sessions above?", len(sessions))) {
+		pterm.Info.Println(noChangesMsg)
+		return nil
+	}
 
-	return db.UpdateSessions(m)
+	return updateSessions(db, startTimes, func(sess *models.Session) {
+		sess.Tags = args
+	})
 }
@@ -0,0 +1,37 @@
+package app
+
+import "github.com/ayoisaiah/focus/internal/apperr"
+
+var (
+	errRenameTagArgs = &apperr.Error{
+		Message: "expected the current and new name of the tag (e.g. focus tags rename old new)",
+	}
+
+	errMergeTagArgs = &apperr.Error{
+		Message: "expected the tags to merge and a target tag (e.g. focus tags merge a b --into c)",
+	}
+
+	errDeleteTagArgs = &apperr.Error{
+		Message: "expected the tag to delete (e.g. focus tags delete x)",
+	}
+
+	errTaskTitleArgs = &apperr.Error{
+		Message: `expected the title of the task (e.g. focus task add "Write migration")`,
+	}
+
+	errTaskIDArgs = &apperr.Error{
+		Message: "expected the ID of the task (e.g. focus task done 3)",
+	}
+
+	errNotGitRepo = &apperr.Error{
+		Message: "%s is not in a git repository: %v",
+	}
+
+	errInvalidGrepPattern = &apperr.Error{
+		Message: "invalid --grep pattern",
+	}
+
+	errInvalidTagName = &apperr.Error{
+		Message: "invalid tag name %q: tags cannot be empty or contain commas",
+	}
+)
@@ -8,6 +8,16 @@ var (
 		Usage: "Start or add a new session in the past (e.g. '20 mins ago'). Must not overlap with any existing sessions",
 	}
 
+	atFlag = &cli.StringFlag{
+		Name:  "at",
+		Usage: "Schedule the first session to start at a future time (e.g. '14:00')",
+	}
+
+	untilFlag = &cli.StringFlag{
+		Name:  "until",
+		Usage: "Fit as many sessions as possible before the specified time (e.g. '17:30')",
+	}
+
 	noColorFlag = &cli.BoolFlag{
 		Name:  "no-color",
 		Usage: "Disable coloured output",
@@ -59,12 +69,134 @@ var (
 		Usage:   "Add comma-delimited tags to a session",
 	}
 
+	filterTagFlag = &cli.StringFlag{
+		Name:    "tag",
+		Aliases: []string{"t"},
+		Usage:   "Match only sessions whose tags satisfy the query (e.g. 'billing AND NOT meeting')",
+	}
+
+	periodFlag = &cli.StringFlag{
+		Name:    "period",
+		Aliases: []string{"p"},
+		Usage:   "Specify a time period for the report. Options: today, yesterday, 7days, 14days,\n\t\t\t\t30days, 90days, 180days, 365days, all-time (default: 7days)",
+	}
+
+	startFlag = &cli.StringFlag{
+		Name:  "start",
+		Usage: "Specify a start date for the report (e.g. '2021-08-06')",
+	}
+
+	endFlag = &cli.StringFlag{
+		Name:  "end",
+		Usage: "Specify an end date for the report (defaults to the current time)",
+	}
+
+	reportFormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   "The format of the report: html or md",
+		Value:   "html",
+	}
+
+	reportOutFlag = &cli.StringFlag{
+		Name:    "out",
+		Aliases: []string{"o"},
+		Usage:   "Write the report to the specified file instead of the standard output",
+	}
+
+	grepFlag = &cli.StringFlag{
+		Name:    "grep",
+		Aliases: []string{"g"},
+		Usage:   "Match only sessions whose notes match the regular expression (case-insensitive)",
+	}
+
+	taskFlag = &cli.UintFlag{
+		Name:  "task",
+		Usage: "Attach work sessions to the task with the specified ID",
+	}
+
+	pickTaskFlag = &cli.BoolFlag{
+		Name:  "pick-task",
+		Usage: "Pick the task to work on from your todo.txt file or Taskwarrior, and tag sessions with its projects and contexts",
+	}
+
+	taskEstimateFlag = &cli.UintFlag{
+		Name:  "est",
+		Usage: "The estimated number of pomodoros needed to complete the task",
+		Value: 1,
+	}
+
+	taskTagFlag = &cli.StringFlag{
+		Name:    "tag",
+		Aliases: []string{"t"},
+		Usage:   "Comma-delimited tags to apply to the sessions attached to the task",
+	}
+
+	taskAllFlag = &cli.BoolFlag{
+		Name:    "all",
+		Aliases: []string{"a"},
+		Usage:   "Include completed tasks",
+	}
+
+	tagsIntoFlag = &cli.StringFlag{
+		Name:     "into",
+		Usage:    "The tag that the specified tags will be merged into",
+		Required: true,
+	}
+
 	statsPortFlag = &cli.UintFlag{
 		Name:  "port",
 		Usage: "Specify the port for the statistics server",
 		Value: 1111,
 	}
 
+	commitsRepoFlag = &cli.StringFlag{
+		Name:  "repo",
+		Usage: "Path to the git repository whose commits are matched to your sessions",
+		Value: ".",
+	}
+
+	statsRepoFlag = &cli.StringFlag{
+		Name:  "repo",
+		Usage: "Include the commits in the specified git repository in the statistics",
+	}
+
+	commitsAuthorFlag = &cli.StringFlag{
+		Name:  "author",
+		Usage: "Only count commits whose author name or email contains the specified text",
+	}
+
+	commitsJSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the statistics for the period as JSON, including the commits",
+	}
+
+	flowFlag = &cli.BoolFlag{
+		Name:  "flow",
+		Usage: "Count work sessions up until you finish them and earn a proportional break",
+	}
+
+	accessibleFlag = &cli.BoolFlag{
+		Name:  "accessible",
+		Usage: "Print changes to the timer as plain text for screen readers instead of drawing it. Commands are typed as a line followed by Enter",
+	}
+
+	maxSessionsFlag = &cli.UintFlag{
+		Name:    "max-sessions",
+		Aliases: []string{"max"},
+		Usage:   "Stop the timer after the specified number of work sessions",
+	}
+
+	presetFlag = &cli.StringFlag{
+		Name:  "preset",
+		Usage: "Follow a named sequence of sessions from the config file, or a pattern such as 52-17",
+	}
+
+	repoFlag = &cli.StringFlag{
+		Name:  "repo",
+		Usage: "Tag sessions with the git repository and branch of the specified directory",
+	}
+
 	shortBreakFlag = &cli.StringFlag{
 		Name:    "short-break",
 		Aliases: []string{"s"},
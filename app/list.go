@@ -3,6 +3,8 @@ package app
 import (
 	"io"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -14,18 +16,24 @@ import (
 
 const (
 	noSessionsMsg = "No sessions found for the specified time range"
+	noChangesMsg  = "No changes were made"
 )
 
-// printSessionsTable prints a session table to the command-line.
+// printSessionsTable prints a session table to the command-line. A notes
+// column is included if any of the sessions have notes.
 func printSessionsTable(w io.Writer, sessions []*models.Session) {
 	tableBody := make([][]string, len(sessions))
 
+	hasNotes := slices.ContainsFunc(sessions, func(sess *models.Session) bool {
+		return sess.Notes != ""
+	})
+
 	for i := range sessions {
 		sess := sessions[i]
 
-		statusText := ui.Green("completed")
+		statusText := ui.Success("completed")
 		if !sess.Completed {
-			statusText = ui.Red("abandoned")
+			statusText = ui.Error("abandoned")
 		}
 
 		endDate := sess.EndTime.Format("Jan 02, 2006 03:04 PM")
@@ -43,16 +51,45 @@ func printSessionsTable(w io.Writer, sessions []*models.Session) {
 			statusText,
 		}
 
+		if hasNotes {
+			row = append(row, sess.Notes)
+		}
+
 		tableBody[i] = row
 	}
 
-	tableBody = append([][]string{
-		{"#", "START DATE", "END DATE", "TAGS", "STATUS"},
-	}, tableBody...)
+	header := []string{"#", "START DATE", "END DATE", "TAGS", "STATUS"}
+	if hasNotes {
+		header = append(header, "NOTES")
+	}
+
+	tableBody = append([][]string{header}, tableBody...)
 
 	ui.PrintTable(tableBody, w)
 }
 
+// grepSessions returns the sessions whose notes match the regular expression.
+// The match is case-insensitive.
+func grepSessions(
+	sessions []*models.Session,
+	pattern string,
+) ([]*models.Session, error) {
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, errInvalidGrepPattern.Wrap(err)
+	}
+
+	var result []*models.Session
+
+	for _, sess := range sessions {
+		if re.MatchString(sess.Notes) {
+			result = append(result, sess)
+		}
+	}
+
+	return result, nil
+}
+
 // listSessions prints out a table of sessions.
 func listSessions(sessions []*models.Session) error {
 	if len(sessions) == 0 {
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+	"github.com/ayoisaiah/focus/internal/ui"
+	"github.com/ayoisaiah/focus/store"
+)
+
+const noTaggedSessionsMsg = "No sessions are tagged with %s"
+
+// tagUsage is the number of sessions and the focus time recorded for a tag.
+type tagUsage struct {
+	name     string
+	sessions int
+	duration time.Duration
+}
+
+// validateTagName ensures that a tag can be stored and filtered on.
+func validateTagName(tag string) error {
+	if strings.TrimSpace(tag) == "" || strings.Contains(tag, ",") {
+		return errInvalidTagName.Fmt(tag)
+	}
+
+	return nil
+}
+
+// listTags prints a table of every tag along with the number of sessions it
+// was applied to and the total focus time of those sessions.
+func listTags(sessions []*models.Session) error {
+	m := make(map[string]*tagUsage)
+
+	for _, sess := range sessions {
+		var d time.Duration
+
+		for _, event := range sess.Timeline {
+			d += event.EndTime.Sub(event.StartTime)
+		}
+
+		for _, tag := range sess.Tags {
+			if m[tag] == nil {
+				m[tag] = &tagUsage{name: tag}
+			}
+
+			m[tag].sessions++
+			m[tag].duration += d
+		}
+	}
+
+	if len(m) == 0 {
+		pterm.Info.Println("No tagged sessions found")
+		return nil
+	}
+
+	usage := make([]*tagUsage, 0, len(m))
+	for _, v := range m {
+		usage = append(usage, v)
+	}
+
+	slices.SortFunc(usage, func(a, b *tagUsage) int {
+		return cmp.Compare(a.name, b.name)
+	})
+
+	tableBody := [][]string{{"TAG", "SESSIONS", "TIME"}}
+
+	for _, u := range usage {
+		hrs, mins := timeutil.MinsToHoursAndMins(int(u.duration.Minutes()))
+
+		tableBody = append(tableBody, []string{
+			u.name,
+			strconv.Itoa(u.sessions),
+			fmt.Sprintf("%dh %dm", hrs, mins),
+		})
+	}
+
+	ui.PrintTable(tableBody, os.Stdout)
+
+	return nil
+}
+
+// replaceTag replaces the old tag and its descendants with the replacement
+// so that renaming `acme` also renames `acme/api` to `replacement/api`. The
+// tags are removed if the replacement is empty. Duplicate tags are dropped.
+func replaceTag(tags []string, old, replacement string) []string {
+	result := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		if tagquery.Within(tag, old) {
+			if replacement == "" {
+				continue
+			}
+
+			tag = replacement + strings.TrimPrefix(tag, old)
+		}
+
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
+// rewriteTags applies the rewrite function to the tags of each session and
+// saves the sessions whose tags changed in a single transaction. It prints the
+// affected sessions and asks for confirmation before proceeding. The database
+// is only opened once the changes are confirmed, and the tags are rewritten
+// again on the sessions as they are saved at that point.
+func rewriteTags(
+	db store.DB,
+	sessions []*models.Session,
+	rewrite func(tags []string) []string,
+	tags string,
+) error {
+	var (
+		changed    []*models.Session
+		startTimes []time.Time
+	)
+
+	for _, sess := range sessions {
+		newTags := rewrite(sess.Tags)
+		if slices.Equal(newTags, sess.Tags) {
+			continue
+		}
+
+		sess.Tags = newTags
+
+		changed = append(changed, sess)
+		startTimes = append(startTimes, sess.StartTime)
+	}
+
+	if len(changed) == 0 {
+		pterm.Info.Printfln(noTaggedSessionsMsg, tags)
+		return nil
+	}
+
+	printSessionsTable(os.Stdout, changed)
+
+	if !confirm(fmt.Sprintf("Update the %d sessions above?", len(changed))) {
+		pterm.Info.Println(noChangesMsg)
+		return nil
+	}
+
+	return updateSessions(db, startTimes, func(sess *models.Session) {
+		sess.Tags = rewrite(sess.Tags)
+	})
+}
+
+// renameTag renames a tag across all sessions.
+func renameTag(
+	db store.DB,
+	sessions []*models.Session,
+	old, replacement string,
+) error {
+	err := validateTagName(replacement)
+	if err != nil {
+		return err
+	}
+
+	return rewriteTags(db, sessions, func(tags []string) []string {
+		return replaceTag(tags, old, replacement)
+	}, old)
+}
+
+// mergeTags replaces each of the specified tags with the target tag.
+func mergeTags(
+	db store.DB,
+	sessions []*models.Session,
+	tags []string,
+	into string,
+) error {
+	err := validateTagName(into)
+	if err != nil {
+		return err
+	}
+
+	return rewriteTags(db, sessions, func(sessTags []string) []string {
+		for _, tag := range tags {
+			sessTags = replaceTag(sessTags, tag, into)
+		}
+
+		return sessTags
+	}, strings.Join(tags, ", "))
+}
+
+// deleteTag removes a tag from all sessions.
+func deleteTag(db store.DB, sessions []*models.Session, tag string) error {
+	return rewriteTags(db, sessions, func(tags []string) []string {
+		return replaceTag(tags, tag, "")
+	}, tag)
+}
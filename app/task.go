@@ -0,0 +1,120 @@
+package app
+
+import (
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/ui"
+	"github.com/ayoisaiah/focus/stats"
+	"github.com/ayoisaiah/focus/store"
+)
+
+// addTask saves a new task with an estimate in pomodoros.
+func addTask(db store.DB, title string, estimate int, tags []string) error {
+	task := &models.Task{
+		Title:     title,
+		Estimate:  estimate,
+		Tags:      tags,
+		CreatedAt: time.Now(),
+	}
+
+	err := db.AddTask(task)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("Added task %d: %s", task.ID, task.Title)
+
+	return nil
+}
+
+// listTasks prints a table of tasks along with the estimated and actual
+// number of pomodoros for each one. Completed tasks are only included if
+// all is true.
+func listTasks(db store.DB, all bool) error {
+	tasks, err := db.GetTasks()
+	if err != nil {
+		return err
+	}
+
+	if !all {
+		tasks = slices.DeleteFunc(tasks, (*models.Task).Done)
+	}
+
+	if len(tasks) == 0 {
+		pterm.Info.Println("No tasks found. Add one with: focus task add")
+		return nil
+	}
+
+	// sessions can only be attached to a task after it is created
+	since := tasks[0].CreatedAt
+	for _, task := range tasks {
+		if task.CreatedAt.Before(since) {
+			since = task.CreatedAt
+		}
+	}
+
+	sessions, err := db.GetSessions(since, time.Now(), nil)
+	if err != nil {
+		return err
+	}
+
+	actual := stats.TaskPomodoros(sessions)
+
+	tableBody := [][]string{{"ID", "TASK", "TAGS", "EST", "ACTUAL", "STATUS"}}
+
+	for _, task := range tasks {
+		statusText := ui.Info("open")
+		if task.Done() {
+			statusText = ui.Success("done")
+		}
+
+		actualText := strconv.Itoa(actual[task.ID])
+		if actual[task.ID] > task.Estimate {
+			actualText = ui.Error(actualText)
+		}
+
+		tableBody = append(tableBody, []string{
+			strconv.Itoa(task.ID),
+			task.Title,
+			strings.Join(task.Tags, " · "),
+			strconv.Itoa(task.Estimate),
+			actualText,
+			statusText,
+		})
+	}
+
+	ui.PrintTable(tableBody, os.Stdout)
+
+	return nil
+}
+
+// completeTask marks a task as done.
+func completeTask(db store.DB, id int) error {
+	task, err := db.GetTask(id)
+	if err != nil {
+		return err
+	}
+
+	if task.Done() {
+		pterm.Info.Printfln("Task %d is already done", task.ID)
+		return nil
+	}
+
+	task.CompletedAt = time.Now()
+
+	err = db.UpdateTask(task)
+	if err != nil {
+		return err
+	}
+
+	pterm.Success.Printfln("Completed task %d: %s", task.ID, task.Title)
+
+	return nil
+}
@@ -8,12 +8,15 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ayoisaiah/focus/internal/gitrepo"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 )
 
 // CLIOptions represents command-line configuration options.
 type CLIOptions struct {
 	Since             string
+	At                string
+	Until             string
 	ShortBreak        string
 	LongBreak         string
 	Tags              string
@@ -22,7 +25,14 @@ type CLIOptions struct {
 	WorkSound         string
 	SessionCmd        string
 	Work              string
+	Preset            string
+	Repo              string
 	LongBreakInterval uint
+	MaxSessions       uint
+	TaskID            uint
+	Flow              bool
+	PickTask          bool
+	Accessible        bool
 	DisableNotify     bool
 	SoundOnBreak      bool
 	Strict            bool
@@ -36,12 +46,21 @@ func WithCLIConfig(ctx *cli.Context) Option {
 			ShortBreak:        ctx.String("short-break"),
 			LongBreak:         ctx.String("long-break"),
 			LongBreakInterval: ctx.Uint("long-break-interval"),
+			TaskID:            ctx.Uint("task"),
+			MaxSessions:       ctx.Uint("max-sessions"),
+			Flow:              ctx.Bool("flow"),
+			PickTask:          ctx.Bool("pick-task"),
+			Accessible:        ctx.Bool("accessible"),
+			Preset:            ctx.String("preset"),
+			Repo:              ctx.String("repo"),
 			Tags:              ctx.String("tag"),
 			AmbientSound:      ctx.String("sound"),
 			BreakSound:        ctx.String("break-sound"),
 			WorkSound:         ctx.String("work-sound"),
 			SessionCmd:        ctx.String("session-cmd"),
 			Since:             ctx.String("since"),
+			At:                ctx.String("at"),
+			Until:             ctx.String("until"),
 			DisableNotify:     ctx.Bool("disable-notification"),
 			SoundOnBreak:      ctx.Bool("sound-on-break"),
 			Strict:            ctx.Bool("strict"),
@@ -61,6 +80,39 @@ func applyCLIOptions(c *Config, opts CLIOptions) error {
 		c.CLI.Tags = splitAndTrimTags(opts.Tags)
 	}
 
+	if opts.PickTask && opts.TaskID != 0 {
+		return errPickWithTask
+	}
+
+	c.CLI.TaskID = int(opts.TaskID)
+	c.CLI.PickTask = opts.PickTask
+	c.CLI.MaxSessions = int(opts.MaxSessions)
+	c.CLI.Flow = opts.Flow
+	c.CLI.Accessible = opts.Accessible
+
+	if opts.Preset != "" {
+		if opts.Flow {
+			return errPresetWithFlow
+		}
+
+		steps, err := c.Preset(opts.Preset)
+		if err != nil {
+			return err
+		}
+
+		c.CLI.Preset = opts.Preset
+		c.CLI.Sequence = steps
+	}
+
+	if opts.Repo != "" {
+		repo, err := gitrepo.Find(opts.Repo)
+		if err != nil {
+			return errRepoNotFound.Fmt(opts.Repo, err)
+		}
+
+		c.CLI.Repo = repo.Root
+	}
+
 	if opts.DisableNotify {
 		c.Notifications.Enabled = false
 	}
@@ -88,6 +140,53 @@ func applyCLIOptions(c *Config, opts CLIOptions) error {
 		c.CLI.StartTime = time.Now()
 	}
 
+	return applyCLISchedule(c, opts)
+}
+
+// applyCLISchedule handles the options that schedule when sessions start and
+// end.
+func applyCLISchedule(c *Config, opts CLIOptions) error {
+	now := time.Now()
+
+	if opts.At != "" {
+		if opts.Since != "" {
+			return errAtWithSince
+		}
+
+		at, err := timeutil.FromStr(opts.At)
+		if err != nil {
+			return fmt.Errorf("invalid at time: %w", err)
+		}
+
+		if !at.After(now) {
+			return errAtInPast.Fmt(at.Format(time.DateTime))
+		}
+
+		c.CLI.At = at
+	}
+
+	if opts.Until != "" {
+		if opts.Flow {
+			return errUntilWithFlow
+		}
+
+		until, err := timeutil.FromStr(opts.Until)
+		if err != nil {
+			return fmt.Errorf("invalid until time: %w", err)
+		}
+
+		start := now
+		if c.CLI.At.After(now) {
+			start = c.CLI.At
+		}
+
+		if until.Sub(start) < minUntilDuration {
+			return errUntilTooSoon.Fmt(until.Format(time.DateTime))
+		}
+
+		c.CLI.Until = until
+	}
+
 	return nil
 }
 
@@ -10,7 +10,9 @@ import (
 
 	"github.com/adrg/xdg"
 
+	"github.com/ayoisaiah/focus/internal/lockfile"
 	"github.com/ayoisaiah/focus/internal/pathutil"
+	"github.com/ayoisaiah/focus/internal/theme"
 	"github.com/ayoisaiah/focus/report"
 )
 
@@ -24,9 +26,20 @@ type (
 		Settings      SettingsConfig `mapstructure:"settings"`
 		Display       DisplayConfig  `mapstructue:"display"`
 		Notifications NotificationConfig
-		firstRun      bool
+		Goals         GoalsConfig               `mapstructure:"goals"`
+		Flow          FlowConfig                `mapstructure:"flow"`
+		Presets       map[string][]SequenceStep `mapstructure:"presets"`
+		Keys          KeysConfig                `mapstructure:"keys"`
+		Git           GitConfig                 `mapstructure:"git"`
+		TaskSource    TaskSourceConfig          `mapstructure:"task_source"`
+		// Theme is the palette selected by Display.Theme with any session
+		// colors from the config file applied.
+		Theme    theme.Theme `mapstructure:"-"`
+		firstRun bool
 	}
 
+	// SessionConfig holds the settings for a type of session. An empty Color
+	// means the session color of the selected theme is used.
 	SessionConfig struct {
 		Message  string        `mapstructure:"message"`
 		Color    string        `mapstructue:"color"`
@@ -36,20 +49,46 @@ type (
 
 	// SettingsConfig contains general application settings.
 	SettingsConfig struct {
-		AmbientSound      string `mapstructure:"ambient_sound"`
-		Cmd               string `mapstructure:"cmd"`
-		LongBreakInterval int    `mapstructure:"long_break_interval"`
-		AutoStartBreak    bool   `mapstructure:"auto_start_break"`
-		AutoStartWork     bool   `mapstructure:"auto_start_work"`
-		SoundOnBreak      bool   `mapstructure:"sound_on_break"`
-		Strict            bool   `mapstructure:"strict"`
-		TwentyFourHour    bool   `mapstructure:"24hr_clock"`
+		AmbientSound      string        `mapstructure:"ambient_sound"`
+		Cmd               string        `mapstructure:"cmd"`
+		AnnounceInterval  time.Duration `mapstructure:"announce_interval"`
+		Extend            time.Duration `mapstructure:"extend"`
+		Snooze            time.Duration `mapstructure:"snooze"`
+		ShutdownChecklist []string      `mapstructure:"shutdown_checklist"`
+		LongBreakInterval int           `mapstructure:"long_break_interval"`
+		MaxSessionsPerDay int           `mapstructure:"max_sessions_per_day"`
+		AutoStartBreak    bool          `mapstructure:"auto_start_break"`
+		AutoStartWork     bool          `mapstructure:"auto_start_work"`
+		NotesPrompt       bool          `mapstructure:"notes_prompt"`
+		SoundOnBreak      bool          `mapstructure:"sound_on_break"`
+		Strict            bool          `mapstructure:"strict"`
+		TwentyFourHour    bool          `mapstructure:"24hr_clock"`
 	}
 
 	// arguments.
 	CLIConfig struct {
 		StartTime time.Time
-		Tags      []string
+		// At is when the first session is scheduled to start.
+		At time.Time
+		// Until is when the last session must end.
+		Until  time.Time
+		Tags   []string
+		Preset string
+		// Repo is the directory whose git repository sessions are tagged
+		// with. It enables auto-tagging for this run of the timer.
+		Repo     string
+		Sequence []SequenceStep
+		TaskID   int
+		// MaxSessions is the number of work sessions after which the timer
+		// stops.
+		MaxSessions int
+		Flow        bool
+		// PickTask asks for a task from the task source before the first
+		// session starts.
+		PickTask bool
+		// Accessible prints state changes as plain lines instead of drawing
+		// the timer, and reads commands a line at a time.
+		Accessible bool
 	}
 
 	// NotificationConfig holds notification settings.
@@ -57,11 +96,108 @@ type (
 		Enabled bool `mapstructure:"enabled"`
 	}
 
-	// DisplayConfig holds display-related settings.
+	// DisplayConfig holds display-related settings. Theme is the name of a
+	// built-in theme or of a theme file in the `themes` directory next to the
+	// config file. If it is empty, the dark or light theme is used depending
+	// on DarkTheme.
 	DisplayConfig struct {
-		DarkTheme bool `mapstructure:"dark_theme"`
+		Mode      DisplayMode `mapstructure:"mode"`
+		Theme     string      `mapstructure:"theme"`
+		DarkTheme bool        `mapstructure:"dark_theme"`
 	}
 
+	// DisplayMode determines how the timer is rendered in the terminal.
+	DisplayMode string
+
+	// GoalsConfig holds the daily and weekly focus targets. A target of zero
+	// disables the goal. If Tags is not empty, only sessions that have at
+	// least one of the listed tags count towards the goals.
+	GoalsConfig struct {
+		Unit   GoalUnit `mapstructure:"unit"`
+		Tags   []string `mapstructure:"tags"`
+		Daily  int      `mapstructure:"daily"`
+		Weekly int      `mapstructure:"weekly"`
+	}
+
+	// FlowConfig determines the length of the break that follows a flowtime
+	// work session. If any rules are specified, the break is taken from the
+	// rule with the longest work duration that the session reached. Otherwise,
+	// or if no rule applies, the break is the work duration divided by Ratio.
+	FlowConfig struct {
+		Rules []FlowRule `mapstructure:"rules"`
+		Ratio float64    `mapstructure:"ratio"`
+	}
+
+	// FlowRule grants a break of the specified length to flowtime work
+	// sessions that last at least as long as Work.
+	FlowRule struct {
+		Work  time.Duration `mapstructure:"work"`
+		Break time.Duration `mapstructure:"break"`
+	}
+
+	// SequenceStep is a session in a named preset. Session is one of `work`,
+	// `short_break`, or `long_break`. The duration and message of the session
+	// type are used if Duration or Message is not specified.
+	SequenceStep struct {
+		Session  string        `mapstructure:"session"`
+		Message  string        `mapstructure:"message"`
+		Duration time.Duration `mapstructure:"duration"`
+	}
+
+	// KeysConfig holds the keys bound to each action in the timer. An action
+	// with no keys is disabled, except that `ctrl+c` always quits.
+	KeysConfig struct {
+		PlayPause            []string `mapstructure:"play_pause"`
+		Sound                []string `mapstructure:"sound"`
+		InternalInterruption []string `mapstructure:"internal_interruption"`
+		ExternalInterruption []string `mapstructure:"external_interruption"`
+		Continue             []string `mapstructure:"continue"`
+		Finish               []string `mapstructure:"finish"`
+		FinishEarly          []string `mapstructure:"finish_early"`
+		Extend               []string `mapstructure:"extend"`
+		Snooze               []string `mapstructure:"snooze"`
+		Display              []string `mapstructure:"display"`
+		Today                []string `mapstructure:"today"`
+		Tags                 []string `mapstructure:"tags"`
+		Skip                 []string `mapstructure:"skip"`
+		Quit                 []string `mapstructure:"quit"`
+	}
+
+	// GitConfig controls the tags that sessions get from the git repository
+	// they are worked in. If AutoTag is enabled, each session is tagged with
+	// `repo:<name>` and `branch:<name>` when it starts, along with the tags
+	// of every rule whose path contains the repository.
+	GitConfig struct {
+		Rules   []GitRule `mapstructure:"rules"`
+		AutoTag bool      `mapstructure:"auto_tag"`
+	}
+
+	// GitRule adds Tags to sessions worked in repositories at or below Path.
+	// A leading `~` in Path is the home directory.
+	GitRule struct {
+		Path string   `mapstructure:"path"`
+		Tags []string `mapstructure:"tags"`
+	}
+
+	// TaskSourceConfig selects the task list that tasks are picked from when
+	// the timer starts. Type is `todotxt` or `taskwarrior`, and File is the
+	// path of the todo.txt file. If Pick is enabled, a task is picked every
+	// time the timer starts without --task. OnComplete is what happens to the
+	// picked task when a work session is completed.
+	TaskSourceConfig struct {
+		Type       string     `mapstructure:"type"`
+		File       string     `mapstructure:"file"`
+		OnComplete TaskAction `mapstructure:"on_complete"`
+		Pick       bool       `mapstructure:"pick"`
+	}
+
+	// TaskAction is what happens to a picked task when a work session is
+	// completed.
+	TaskAction string
+
+	// GoalUnit is the unit in which goals are measured.
+	GoalUnit string
+
 	// Option is a function that modifies Config.
 	Option func(*Config) error
 
@@ -71,6 +207,36 @@ type (
 
 const Version = "v1.4.2"
 
+const (
+	DisplayStandard   DisplayMode = "standard"
+	DisplayFullscreen DisplayMode = "fullscreen"
+	DisplayCompact    DisplayMode = "compact"
+	DisplayMinimal    DisplayMode = "minimal"
+)
+
+// DisplayModes lists the display modes in the order they are cycled through in
+// the timer.
+var DisplayModes = []DisplayMode{
+	DisplayStandard,
+	DisplayFullscreen,
+	DisplayCompact,
+	DisplayMinimal,
+}
+
+const (
+	GoalPomodoros GoalUnit = "pomodoros"
+	GoalMinutes   GoalUnit = "minutes"
+)
+
+const (
+	// TaskActionNone leaves the task as it is.
+	TaskActionNone TaskAction = ""
+	// TaskActionDone marks the task as done.
+	TaskActionDone TaskAction = "done"
+	// TaskActionNote adds the notes of the session to the task.
+	TaskActionNote TaskAction = "note"
+)
+
 const (
 	Work       SessionType = "Work session"
 	ShortBreak SessionType = "Short break"
@@ -82,10 +248,12 @@ var (
 	configFile     = "config.yml"
 	dbFile         = "focus.db"
 	statusFile     = "status.json"
+	lockFile       = "focus.lock"
 	logFile        = "focus.log"
 	dbFilePath     string
 	configFilePath string
 	statusFilePath string
+	lockFilePath   string
 )
 
 var (
@@ -101,6 +269,7 @@ func init() {
 		configFile = fmt.Sprintf("config_%s.yml", focusEnv)
 		dbFile = fmt.Sprintf("focus_%s.db", focusEnv)
 		statusFile = fmt.Sprintf("status_%s.json", focusEnv)
+		lockFile = fmt.Sprintf("focus_%s.lock", focusEnv)
 	}
 
 	var err error
@@ -114,7 +283,25 @@ func init() {
 	if err != nil {
 		report.Quit(err)
 	}
-	// statusFilePath = filepath.Join(dataDir, statusFile)
+
+	statusFilePath, err = xdg.DataFile(filepath.Join(appName, statusFile))
+	if err != nil {
+		report.Quit(err)
+	}
+
+	lockFilePath, err = xdg.DataFile(filepath.Join(appName, lockFile))
+	if err != nil {
+		report.Quit(err)
+	}
+}
+
+// SetDataDir keeps the database, status and lock files in dir instead of the
+// data directory of the user, so that tests don't interfere with a running
+// timer.
+func SetDataDir(dir string) {
+	dbFilePath = filepath.Join(dir, dbFile)
+	statusFilePath = filepath.Join(dir, statusFile)
+	lockFilePath = filepath.Join(dir, lockFile)
 }
 
 func Dir() string {
@@ -137,6 +324,18 @@ func StatusFilePath() string {
 	return statusFilePath
 }
 
+// TimerRunning reports whether a timer is running in another process. A
+// running timer holds a lock on the lock file until it exits.
+func TimerRunning() bool {
+	return lockfile.Held(lockFilePath)
+}
+
+// LockFilePath returns the path of the file that is locked by the running
+// timer.
+func LockFilePath() string {
+	return lockFilePath
+}
+
 func ConfigFilePath() string {
 	return configFilePath
 }
@@ -154,6 +353,31 @@ func SoundOpts() []string {
 	return sounds
 }
 
+// BreakFor returns the length of the break earned by a flowtime work session
+// of the specified length.
+func (f FlowConfig) BreakFor(worked time.Duration) time.Duration {
+	var (
+		rule   *FlowRule
+		earned time.Duration
+	)
+
+	for i := range f.Rules {
+		if worked >= f.Rules[i].Work && (rule == nil || f.Rules[i].Work > rule.Work) {
+			rule = &f.Rules[i]
+		}
+	}
+
+	if rule != nil {
+		return rule.Break
+	}
+
+	if f.Ratio > 0 {
+		earned = time.Duration(float64(worked) / f.Ratio).Round(time.Second)
+	}
+
+	return earned
+}
+
 // New creates a new Config with default values and applies options.
 func New(opts ...Option) (*Config, error) {
 	cfg := &Config{}
@@ -9,7 +9,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/gitrepo"
 	"github.com/ayoisaiah/focus/internal/testutil"
+	"github.com/ayoisaiah/focus/internal/theme"
 )
 
 type TestCase struct {
@@ -23,24 +25,51 @@ func (t TestCase) Output() (out []byte, name string) {
 	return t.Snapshot, t.GoldenFile
 }
 
+// builtinTheme returns the built-in theme with the specified name.
+func builtinTheme(name string) theme.Theme {
+	t, _ := theme.Builtin(name)
+
+	return t
+}
+
+// defaultKeys returns the default key bindings.
+func defaultKeys() config.KeysConfig {
+	return config.KeysConfig{
+		PlayPause:            []string{"p"},
+		Sound:                []string{"s"},
+		InternalInterruption: []string{"i"},
+		ExternalInterruption: []string{"e"},
+		Continue:             []string{"enter"},
+		Finish:               []string{"enter"},
+		FinishEarly:          []string{"f"},
+		Extend:               []string{"+", "="},
+		Snooze:               []string{"z"},
+		Display:              []string{"d"},
+		Today:                []string{"t"},
+		Tags:                 []string{"#"},
+		Skip:                 []string{"esc"},
+		Quit:                 []string{"q"},
+	}
+}
+
 // defaultConfig returns a new Config instance with default values.
 func defaultConfig() *config.Config {
 	return &config.Config{
 		Work: config.SessionConfig{
 			Message:  "Focus on your task",
-			Color:    "#B0DB43",
+			Color:    "",
 			Sound:    "loud_bell",
 			Duration: 25 * time.Minute,
 		},
 		ShortBreak: config.SessionConfig{
 			Message:  "Take a breather",
-			Color:    "#12EAEA",
+			Color:    "",
 			Sound:    "bell",
 			Duration: 5 * time.Minute,
 		},
 		LongBreak: config.SessionConfig{
 			Message:  "Take a long break",
-			Color:    "#C492B1",
+			Color:    "",
 			Sound:    "bell",
 			Duration: 15 * time.Minute,
 		},
@@ -49,17 +78,36 @@ func defaultConfig() *config.Config {
 			AutoStartBreak:    true,
 			AutoStartWork:     false,
 			Cmd:               "",
+			Extend:            5 * time.Minute,
+			Snooze:            5 * time.Minute,
+			AnnounceInterval:  5 * time.Minute,
 			LongBreakInterval: 4,
+			ShutdownChecklist: []string{},
 			SoundOnBreak:      false,
+			NotesPrompt:       false,
 			Strict:            false,
-			TwentyFourHour:    false,
+			TwentyFourHour:    true,
 		},
 		Notifications: config.NotificationConfig{
 			Enabled: true,
 		},
 		Display: config.DisplayConfig{
+			Mode:      config.DisplayStandard,
 			DarkTheme: true,
 		},
+		Keys:  defaultKeys(),
+		Theme: builtinTheme(theme.Dark),
+		Goals: config.GoalsConfig{
+			Unit: config.GoalPomodoros,
+			Tags: []string{},
+		},
+		Flow: config.FlowConfig{
+			Rules: []config.FlowRule{},
+			Ratio: 5,
+		},
+		Git: config.GitConfig{
+			Rules: []config.GitRule{},
+		},
 	}
 }
 
@@ -91,6 +139,14 @@ func TestViperWriteConfig(t *testing.T) {
 }
 
 func TestViperReadConfig(t *testing.T) {
+	colorblind := builtinTheme(theme.ColorblindSafe)
+	colorblind.Work = "#B0DB43"
+	colorblind.ShortBreak = "#12EAEA"
+
+	keys := defaultKeys()
+	keys.FinishEarly = []string{"F"}
+	keys.Quit = []string{}
+
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yml")
 
@@ -116,7 +172,7 @@ func TestViperReadConfig(t *testing.T) {
 			},
 			LongBreak: config.SessionConfig{
 				Message:  "Rest a little longer",
-				Color:    "#C492B1",
+				Color:    "",
 				Sound:    "loud_bell",
 				Duration: 30 * time.Minute,
 			},
@@ -125,17 +181,62 @@ func TestViperReadConfig(t *testing.T) {
 				AutoStartBreak:    true,
 				AutoStartWork:     false,
 				Cmd:               "",
+				Extend:            10 * time.Minute,
+				Snooze:            5 * time.Minute,
+				AnnounceInterval:  5 * time.Minute,
 				LongBreakInterval: 6,
-				SoundOnBreak:      false,
-				Strict:            false,
-				TwentyFourHour:    false,
+				MaxSessionsPerDay: 8,
+				ShutdownChecklist: []string{
+					"Review tomorrow's calendar",
+					"Close open tabs",
+				},
+				SoundOnBreak:   false,
+				NotesPrompt:    false,
+				Strict:         false,
+				TwentyFourHour: true,
 			},
 			Notifications: config.NotificationConfig{
 				Enabled: true,
 			},
 			Display: config.DisplayConfig{
+				Mode:      config.DisplayCompact,
+				Theme:     theme.ColorblindSafe,
 				DarkTheme: true,
 			},
+			Goals: config.GoalsConfig{
+				Unit:   config.GoalMinutes,
+				Tags:   []string{"deep-work"},
+				Daily:  240,
+				Weekly: 1200,
+			},
+			Flow: config.FlowConfig{
+				Rules: []config.FlowRule{
+					{Work: 25 * time.Minute, Break: 5 * time.Minute},
+					{Work: 50 * time.Minute, Break: 8 * time.Minute},
+					{Work: 90 * time.Minute, Break: 15 * time.Minute},
+				},
+				Ratio: 4,
+			},
+			Presets: map[string][]config.SequenceStep{
+				"deep": {
+					{Session: "work", Message: "Deep work", Duration: 90 * time.Minute},
+					{Session: "long_break"},
+				},
+			},
+			Git: config.GitConfig{
+				AutoTag: true,
+				Rules: []config.GitRule{
+					{Path: "~/code/focus", Tags: []string{"project:focus"}},
+				},
+			},
+			TaskSource: config.TaskSourceConfig{
+				Type:       "todotxt",
+				File:       "~/todo.txt",
+				OnComplete: config.TaskActionDone,
+				Pick:       true,
+			},
+			Keys:  keys,
+			Theme: colorblind,
 		},
 	}
 
@@ -148,3 +249,326 @@ func TestViperReadConfig(t *testing.T) {
 
 	assert.Equal(t, tc.Want, cfg)
 }
+
+func TestFlowBreakFor(t *testing.T) {
+	flow := config.FlowConfig{
+		Rules: []config.FlowRule{
+			{Work: 50 * time.Minute, Break: 8 * time.Minute},
+			{Work: 25 * time.Minute, Break: 5 * time.Minute},
+		},
+		Ratio: 5,
+	}
+
+	testCases := []struct {
+		Name   string
+		Flow   config.FlowConfig
+		Worked time.Duration
+		Want   time.Duration
+	}{
+		{
+			Name:   "ratio without rules",
+			Flow:   config.FlowConfig{Ratio: 5},
+			Worked: 40 * time.Minute,
+			Want:   8 * time.Minute,
+		},
+		{
+			Name:   "longest rule reached",
+			Flow:   flow,
+			Worked: time.Hour,
+			Want:   8 * time.Minute,
+		},
+		{
+			Name:   "shorter rule",
+			Flow:   flow,
+			Worked: 30 * time.Minute,
+			Want:   5 * time.Minute,
+		},
+		{
+			Name:   "ratio when no rule applies",
+			Flow:   flow,
+			Worked: 10 * time.Minute,
+			Want:   2 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, tc.Flow.BreakFor(tc.Worked))
+		})
+	}
+}
+
+func TestPreset(t *testing.T) {
+	cfg := &config.Config{
+		Presets: map[string][]config.SequenceStep{
+			"deep": {
+				{Session: "work", Duration: 90 * time.Minute},
+				{Session: "long_break"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name   string
+		Preset string
+		Want   []config.SequenceStep
+	}{
+		{
+			Name:   "preset from config file",
+			Preset: "Deep",
+			Want:   cfg.Presets["deep"],
+		},
+		{
+			Name:   "built-in preset",
+			Preset: "desktime",
+			Want: []config.SequenceStep{
+				{Session: "work", Duration: 52 * time.Minute},
+				{Session: "short_break", Duration: 17 * time.Minute},
+			},
+		},
+		{
+			Name:   "pattern with long break",
+			Preset: "90-20-90-20-long",
+			Want: []config.SequenceStep{
+				{Session: "work", Duration: 90 * time.Minute},
+				{Session: "short_break", Duration: 20 * time.Minute},
+				{Session: "work", Duration: 90 * time.Minute},
+				{Session: "short_break", Duration: 20 * time.Minute},
+				{Session: "long_break"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			steps, err := cfg.Preset(tc.Preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.Want, steps)
+		})
+	}
+
+	for _, preset := range []string{"unknown", "52-0", "52--17"} {
+		t.Run(preset, func(t *testing.T) {
+			_, err := cfg.Preset(preset)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestThemeFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	err := os.WriteFile(configPath, []byte("display:\n    theme: solarized\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Mkdir(filepath.Join(tmpDir, "themes"), 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(
+		filepath.Join(tmpDir, "themes", "solarized.yml"),
+		[]byte("base: light\naccent: '#268BD2'\nprogress_end: '#2AA198'\n"),
+		0o600,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := builtinTheme(theme.Light)
+	want.Name = "solarized"
+	want.Accent = "#268BD2"
+	want.ProgressEnd = "#2AA198"
+
+	cfg, err := config.New(config.WithViperConfig(configPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, want, cfg.Theme)
+
+	palette, err := config.LoadTheme(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, want, palette)
+}
+
+func TestUnknownTheme(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yml")
+
+	err := os.WriteFile(configPath, []byte("display:\n    theme: missing\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.New(config.WithViperConfig(configPath))
+
+	assert.ErrorContains(t, err, `unknown theme "missing"`)
+}
+
+func TestKeyConflict(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Keys  string
+		Error string
+	}{
+		{
+			Name:  "finish and continue share a key",
+			Keys:  "finish: [n]\n    continue: [n]",
+			Error: "",
+		},
+		{
+			Name:  "ctrl+c is reserved for quitting",
+			Keys:  "display: [ctrl+c]",
+			Error: `key "ctrl+c" cannot be bound to both display and quit`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yml")
+
+			err := os.WriteFile(configPath, []byte("keys:\n    "+tc.Keys+"\n"), 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = config.New(config.WithViperConfig(configPath))
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorContains(t, err, tc.Error)
+		})
+	}
+}
+
+func TestTaskSourceValidation(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Source string
+		Error  string
+	}{
+		{
+			Name:   "todo.txt file",
+			Source: "type: todotxt\n    file: ~/todo.txt\n    on_complete: done",
+			Error:  "",
+		},
+		{
+			Name:   "taskwarrior notes",
+			Source: "type: taskwarrior\n    on_complete: note",
+			Error:  "",
+		},
+		{
+			Name:   "unknown source",
+			Source: "type: jira",
+			Error:  `unknown task source "jira"`,
+		},
+		{
+			Name:   "missing todo.txt file",
+			Source: "type: todotxt",
+			Error:  "task_source.file must be set",
+		},
+		{
+			Name:   "notes on todo.txt tasks",
+			Source: "type: todotxt\n    file: todo.txt\n    on_complete: note",
+			Error:  "notes cannot be added to todo.txt tasks",
+		},
+		{
+			Name:   "unknown action",
+			Source: "type: taskwarrior\n    on_complete: delete",
+			Error:  `task_source.on_complete must be done or note, got "delete"`,
+		},
+		{
+			Name:   "pick without a source",
+			Source: "pick: true",
+			Error:  "set task_source.type in the config file to pick a task",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yml")
+
+			err := os.WriteFile(
+				configPath,
+				[]byte("task_source:\n    "+tc.Source+"\n"),
+				0o600,
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = config.New(config.WithViperConfig(configPath))
+			if tc.Error == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.ErrorContains(t, err, tc.Error)
+		})
+	}
+}
+
+func TestGitTags(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	git := config.GitConfig{
+		AutoTag: true,
+		Rules: []config.GitRule{
+			{Path: "~/code", Tags: []string{"code"}},
+			{Path: "~/code/focus", Tags: []string{"project:focus", "code"}},
+			{Path: "~/code/focus-web", Tags: []string{"project:web"}},
+		},
+	}
+
+	testCases := []struct {
+		Name string
+		Repo gitrepo.Repo
+		Want []string
+	}{
+		{
+			Name: "repository matched by several rules",
+			Repo: gitrepo.Repo{
+				Root:   filepath.Join(home, "code", "focus"),
+				Branch: "feat/git-tags",
+			},
+			Want: []string{
+				"repo:focus",
+				"branch:feat/git-tags",
+				"code",
+				"project:focus",
+			},
+		},
+		{
+			Name: "detached HEAD outside every rule",
+			Repo: gitrepo.Repo{
+				Root: filepath.Join(home, "src", "dotfiles"),
+				Head: "3e8c9197c2ab1d7e0f5a1c4b0e2d7f6a9b8c1d2e",
+			},
+			Want: []string{"repo:dotfiles"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Want, git.GitTags(tc.Repo))
+		})
+	}
+}
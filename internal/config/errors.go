@@ -70,4 +70,120 @@ var (
 	errInvalidCLIDuration = &apperr.Error{
 		Message: "invalid duration for %s: %v",
 	}
+
+	errInvalidDisplayMode = &apperr.Error{
+		Message: "display mode must be 'standard', 'fullscreen', 'compact', or 'minimal', got %s",
+	}
+
+	errUnknownTheme = &apperr.Error{
+		Message: "unknown theme %q: use one of %s or add %s",
+	}
+
+	errReadTheme = &apperr.Error{
+		Message: "reading theme file failed",
+	}
+
+	errInvalidThemeColor = &apperr.Error{
+		Message: "%s color in theme %q must be a valid hex color code (e.g. #FF0000), got %q",
+	}
+
+	errKeyConflict = &apperr.Error{
+		Message: "key %q cannot be bound to both %s and %s",
+	}
+
+	errEmptyKey = &apperr.Error{
+		Message: "the keys bound to %s cannot be empty strings",
+	}
+
+	errInvalidGoalUnit = &apperr.Error{
+		Message: "goal unit must be 'pomodoros' or 'minutes', got %s",
+	}
+
+	errNegativeGoal = &apperr.Error{
+		Message: "daily and weekly goals cannot be negative",
+	}
+
+	errNegativeMaxSessions = &apperr.Error{
+		Message: "the maximum number of sessions per day cannot be negative",
+	}
+
+	errNegativeAnnounceInterval = &apperr.Error{
+		Message: "the announce interval cannot be negative",
+	}
+
+	errAtWithSince = &apperr.Error{
+		Message: "the --at and --since options cannot be used together",
+	}
+
+	errAtInPast = &apperr.Error{
+		Message: "the --at time (%s) must be in the future",
+	}
+
+	errUntilWithFlow = &apperr.Error{
+		Message: "the --until and --flow options cannot be used together",
+	}
+
+	errUntilTooSoon = &apperr.Error{
+		Message: "the --until time (%s) must be at least a minute after the first session starts",
+	}
+
+	errInvalidFlowRatio = &apperr.Error{
+		Message: "flow ratio must be greater than zero, got %v",
+	}
+
+	errNegativeFlowRule = &apperr.Error{
+		Message: "flow rule durations cannot be negative",
+	}
+
+	errUnknownPreset = &apperr.Error{
+		Message: "unknown preset %s: define it in the config file or use a pattern such as 52-17",
+	}
+
+	errPresetStart = &apperr.Error{
+		Message: "preset %s must start with a work session",
+	}
+
+	errInvalidPresetSession = &apperr.Error{
+		Message: "preset %s: unknown session %q (must be work, short_break, or long_break)",
+	}
+
+	errInvalidPresetDuration = &apperr.Error{
+		Message: "preset %s: session durations must be between %v and %v",
+	}
+
+	errPresetWithFlow = &apperr.Error{
+		Message: "the --preset and --flow options cannot be used together",
+	}
+
+	errRepoNotFound = &apperr.Error{
+		Message: "the --repo path (%s) is not in a git repository: %v",
+	}
+
+	errInvalidGitRule = &apperr.Error{
+		Message: "git rule %d must have a path and at least one tag",
+	}
+
+	errPickWithTask = &apperr.Error{
+		Message: "the --pick-task and --task options cannot be used together",
+	}
+
+	errUnknownTaskSource = &apperr.Error{
+		Message: "unknown task source %q (must be todotxt or taskwarrior)",
+	}
+
+	errTodoFileMissing = &apperr.Error{
+		Message: "task_source.file must be set to the path of your todo.txt file",
+	}
+
+	errInvalidTaskAction = &apperr.Error{
+		Message: "task_source.on_complete must be done or note, got %q",
+	}
+
+	errTodoNotes = &apperr.Error{
+		Message: "notes cannot be added to todo.txt tasks: set task_source.on_complete to done or leave it empty",
+	}
+
+	errNoTaskSource = &apperr.Error{
+		Message: "set task_source.type in the config file to pick a task",
+	}
 )
@@ -10,6 +10,7 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ayoisaiah/focus/internal/tagquery"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 )
 
@@ -18,7 +19,7 @@ import (
 type FilterConfig struct {
 	StartTime time.Time
 	EndTime   time.Time
-	Tags      []string
+	Tags      *tagquery.Query
 }
 
 var (
@@ -69,10 +70,13 @@ func getTimeRange(period timeutil.Period) (start, end time.Time) {
 func setFilterConfig(ctx *cli.Context) (*FilterConfig, error) {
 	filterCfg := &FilterConfig{}
 
-	if (ctx.String("tag")) != "" {
-		filterCfg.Tags = strings.Split(ctx.String("tag"), ",")
+	tags, err := tagquery.Parse(ctx.String("tag"))
+	if err != nil {
+		return nil, err
 	}
 
+	filterCfg.Tags = tags
+
 	period := timeutil.Period(strings.TrimSpace(ctx.String("period")))
 
 	if period != "" && !slices.Contains(timeutil.PeriodCollection, period) {
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ayoisaiah/focus/internal/gitrepo"
+)
+
+const (
+	repoTagPrefix   = "repo:"
+	branchTagPrefix = "branch:"
+)
+
+// GitTagsEnabled reports whether sessions are tagged with the git repository
+// they are worked in, either because it is enabled in the config file or
+// because a repository was specified with --repo.
+func (c *Config) GitTagsEnabled() bool {
+	return c.Git.AutoTag || c.CLI.Repo != ""
+}
+
+// GitTags returns the tags for sessions worked in repo: the name of the
+// repository, the branch that is checked out, and the tags of every rule whose
+// path contains the repository. No branch tag is added if HEAD is detached.
+func (g GitConfig) GitTags(repo gitrepo.Repo) []string {
+	tags := []string{repoTagPrefix + repo.Name()}
+
+	if repo.Branch != "" {
+		tags = append(tags, branchTagPrefix+repo.Branch)
+	}
+
+	for _, rule := range g.Rules {
+		if !containsPath(expandHome(rule.Path), repo.Root) {
+			continue
+		}
+
+		for _, tag := range rule.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	return tags
+}
+
+// containsPath reports whether path is dir or one of its descendants.
+func containsPath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// expandHome replaces a leading `~` in path with the home directory.
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~")
+	if !ok || rest != "" && rest[0] != '/' && rest[0] != filepath.Separator {
+		return filepath.Clean(path)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Clean(path)
+	}
+
+	return filepath.Join(home, rest)
+}
@@ -0,0 +1,39 @@
+package config
+
+import "slices"
+
+// keyBinding is the name of an action in the keys section of the config file
+// and the keys bound to it.
+type keyBinding struct {
+	action string
+	keys   []string
+}
+
+// bindings returns every action in the timer with the keys bound to it.
+func (k KeysConfig) bindings() []keyBinding {
+	return []keyBinding{
+		{"play_pause", k.PlayPause},
+		{"sound", k.Sound},
+		{"internal_interruption", k.InternalInterruption},
+		{"external_interruption", k.ExternalInterruption},
+		{"continue", k.Continue},
+		{"finish", k.Finish},
+		{"finish_early", k.FinishEarly},
+		{"extend", k.Extend},
+		{"snooze", k.Snooze},
+		{"display", k.Display},
+		{"today", k.Today},
+		{"tags", k.Tags},
+		{"skip", k.Skip},
+		{"quit", slices.Concat(k.Quit, []string{"ctrl+c"})},
+	}
+}
+
+// canShareKeys reports whether two actions may be bound to the same key
+// because they never apply at the same time. Finish only applies while a
+// flowtime work session is running, and continue only between sessions.
+func canShareKeys(a, b string) bool {
+	return a == b ||
+		(a == "finish" && b == "continue") ||
+		(a == "continue" && b == "finish")
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	stepWork       = "work"
+	stepShortBreak = "short_break"
+	stepLongBreak  = "long_break"
+
+	// patternLongBreak denotes a long break of the configured duration in a
+	// sequence pattern.
+	patternLongBreak = "long"
+)
+
+// builtinPresets are the presets that are available without being defined in
+// the config file. A preset in the config file with the same name takes
+// precedence.
+var builtinPresets = map[string]string{
+	"desktime":  "52-17",
+	"ultradian": "90-20",
+}
+
+// stepTypes maps the session names used in presets to session types.
+var stepTypes = map[string]SessionType{
+	stepWork:       Work,
+	stepShortBreak: ShortBreak,
+	stepLongBreak:  LongBreak,
+}
+
+// Type returns the type of session of the step.
+func (s SequenceStep) Type() SessionType {
+	return stepTypes[s.Session]
+}
+
+// Preset returns the sequence of sessions for a preset. The name is looked up
+// in the presets defined in the config file and then in the built-in presets.
+// Otherwise, it is parsed as a sequence pattern such as `52-17` or
+// `90-20-90-20-long`, where numbers are session lengths in minutes that
+// alternate between work and short breaks, and `long` is a long break.
+func (c *Config) Preset(name string) ([]SequenceStep, error) {
+	key := strings.ToLower(name)
+
+	if steps, ok := c.Presets[key]; ok {
+		return steps, nil
+	}
+
+	pattern, ok := builtinPresets[key]
+	if !ok {
+		pattern = name
+	}
+
+	steps, ok := parseSequence(pattern)
+	if !ok {
+		return nil, errUnknownPreset.Fmt(name)
+	}
+
+	return steps, nil
+}
+
+// parseSequence parses a sequence pattern such as `90-20-90-20-long`. It
+// reports whether the pattern is valid.
+func parseSequence(pattern string) ([]SequenceStep, bool) {
+	var steps []SequenceStep
+
+	for _, tok := range strings.Split(pattern, "-") {
+		tok = strings.TrimSpace(tok)
+
+		if tok == patternLongBreak {
+			steps = append(steps, SequenceStep{Session: stepLongBreak})
+
+			continue
+		}
+
+		mins, err := strconv.Atoi(tok)
+		if err != nil || mins <= 0 {
+			return nil, false
+		}
+
+		session := stepWork
+		if len(steps) > 0 && steps[len(steps)-1].Session == stepWork {
+			session = stepShortBreak
+		}
+
+		steps = append(steps, SequenceStep{
+			Session:  session,
+			Duration: time.Duration(mins) * time.Minute,
+		})
+	}
+
+	return steps, true
+}
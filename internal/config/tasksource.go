@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/ayoisaiah/focus/internal/tasksource"
+)
+
+// PickTaskEnabled reports whether a task is picked from the task source when
+// the timer starts, either because it was requested with --pick-task or
+// because it is enabled in the config file and no task was specified with
+// --task.
+func (c *Config) PickTaskEnabled() bool {
+	return c.CLI.PickTask || c.TaskSource.Pick && c.CLI.TaskID == 0
+}
+
+// Source returns the task source selected in the config file. A leading `~`
+// in the path of the todo.txt file is the home directory.
+func (ts TaskSourceConfig) Source() (tasksource.Source, error) {
+	file := ts.File
+	if file != "" {
+		file = expandHome(file)
+	}
+
+	return tasksource.New(ts.Type, file)
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/ayoisaiah/focus/internal/theme"
+)
+
+const (
+	themeDir = "themes"
+
+	// keyThemeBase is the built-in theme that a theme file extends.
+	keyThemeBase = "base"
+)
+
+// themeName returns the name of the selected theme.
+func (c *Config) themeName() string {
+	if c.Display.Theme != "" {
+		return c.Display.Theme
+	}
+
+	if c.Display.DarkTheme {
+		return theme.Dark
+	}
+
+	return theme.Light
+}
+
+// loadTheme returns the selected theme with the session colors from the config
+// file applied. A name that isn't a built-in theme is read from
+// `themes/<name>.yml` in dir. Theme files only need to specify the colors that
+// differ from the built-in theme named by the `base` key (dark by default).
+func (c *Config) loadTheme(dir string) (theme.Theme, error) {
+	name := c.themeName()
+
+	t, ok := theme.Builtin(name)
+	if !ok {
+		var err error
+
+		t, err = readThemeFile(dir, name)
+		if err != nil {
+			return t, err
+		}
+	}
+
+	for _, override := range []struct {
+		color  *string
+		config string
+	}{
+		{&t.Work, c.Work.Color},
+		{&t.ShortBreak, c.ShortBreak.Color},
+		{&t.LongBreak, c.LongBreak.Color},
+	} {
+		if override.config != "" {
+			*override.color = override.config
+		}
+	}
+
+	return t, nil
+}
+
+// readThemeFile reads and validates the theme file for name in dir.
+func readThemeFile(dir, name string) (theme.Theme, error) {
+	path := filepath.Join(dir, themeDir, name+".yml")
+
+	v := viper.New()
+
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	v.SetDefault(keyThemeBase, theme.Dark)
+
+	err := v.ReadInConfig()
+	if errors.Is(err, os.ErrNotExist) {
+		return theme.Theme{}, errUnknownTheme.Fmt(
+			name,
+			strings.Join(theme.Names(), ", "),
+			path,
+		)
+	}
+
+	if err != nil {
+		return theme.Theme{}, errReadTheme.Wrap(err)
+	}
+
+	base := v.GetString(keyThemeBase)
+
+	t, ok := theme.Builtin(base)
+	if !ok {
+		return t, errUnknownTheme.Fmt(
+			base,
+			strings.Join(theme.Names(), ", "),
+			path,
+		)
+	}
+
+	if err := v.Unmarshal(&t); err != nil {
+		return t, errReadTheme.Wrap(err)
+	}
+
+	t.Name = name
+
+	colors := t.Colors()
+
+	keys := make([]string, 0, len(colors))
+	for k := range colors {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if !hexColorRegex.MatchString(colors[k]) {
+			return t, errInvalidThemeColor.Fmt(k, name, colors[k])
+		}
+	}
+
+	return t, nil
+}
+
+// LoadTheme returns the theme selected in the config file at configPath
+// without creating or validating the rest of the config. It is used to style
+// the output of commands that don't otherwise read the config.
+func LoadTheme(configPath string) (theme.Theme, error) {
+	v := viper.New()
+
+	v.SetConfigFile(configPath)
+	v.SetConfigType("yaml")
+
+	c := &Config{}
+
+	setupViper(v, c)
+
+	err := v.ReadInConfig()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return theme.Theme{}, errReadConfig.Wrap(err)
+	}
+
+	if err := v.Unmarshal(c); err != nil {
+		return theme.Theme{}, errReadConfig.Wrap(err)
+	}
+
+	return c.loadTheme(filepath.Dir(configPath))
+}
@@ -9,6 +9,8 @@ import (
 	"slices"
 	"strings"
 	"time"
+
+	"github.com/ayoisaiah/focus/internal/tasksource"
 )
 
 var (
@@ -16,6 +18,9 @@ var (
 	minSessionDuration = 1 * time.Second
 	maxSessionDuration = 720 * time.Minute // 12 hours
 
+	// minUntilDuration is the shortest time that can be planned with --until.
+	minUntilDuration = 1 * time.Minute
+
 	// Valid long break intervals.
 	minLongBreakInterval = 4
 	maxLongBreakInterval = 10
@@ -46,6 +51,34 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if !slices.Contains(DisplayModes, c.Display.Mode) {
+		return errInvalidDisplayMode.Fmt(c.Display.Mode)
+	}
+
+	if err := c.validateGoals(); err != nil {
+		return err
+	}
+
+	if err := c.validateFlow(); err != nil {
+		return err
+	}
+
+	if err := c.validatePresets(); err != nil {
+		return err
+	}
+
+	if err := c.validateKeys(); err != nil {
+		return err
+	}
+
+	if err := c.validateGit(); err != nil {
+		return err
+	}
+
+	if err := c.validateTaskSource(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -66,7 +99,7 @@ func (c *Config) validateSessionConfig(
 		return errEmptyMsg.Fmt(sessionType)
 	}
 
-	if !hexColorRegex.MatchString(sc.Color) {
+	if sc.Color != "" && !hexColorRegex.MatchString(sc.Color) {
 		return errInvalidColor.Fmt(sessionType, sc.Color)
 	}
 
@@ -86,6 +119,32 @@ func (c *Config) validateSettings() error {
 		return errInvalidDuration
 	}
 
+	if c.Settings.Extend < minSessionDuration ||
+		c.Settings.Extend > maxSessionDuration {
+		return errInvalidDuration.Fmt(
+			"extend",
+			minSessionDuration,
+			maxSessionDuration,
+		)
+	}
+
+	if c.Settings.Snooze < minSessionDuration ||
+		c.Settings.Snooze > maxSessionDuration {
+		return errInvalidDuration.Fmt(
+			"snooze",
+			minSessionDuration,
+			maxSessionDuration,
+		)
+	}
+
+	if c.Settings.MaxSessionsPerDay < 0 {
+		return errNegativeMaxSessions
+	}
+
+	if c.Settings.AnnounceInterval < 0 {
+		return errNegativeAnnounceInterval
+	}
+
 	if c.Settings.AmbientSound != "" {
 		if err := c.validateSound(c.Settings.AmbientSound, "ambient"); err != nil {
 			return err
@@ -95,6 +154,140 @@ func (c *Config) validateSettings() error {
 	return nil
 }
 
+// validateGoals validates the GoalsConfig.
+func (c *Config) validateGoals() error {
+	if c.Goals.Unit != GoalPomodoros && c.Goals.Unit != GoalMinutes {
+		return errInvalidGoalUnit.Fmt(c.Goals.Unit)
+	}
+
+	if c.Goals.Daily < 0 || c.Goals.Weekly < 0 {
+		return errNegativeGoal
+	}
+
+	return nil
+}
+
+// validateFlow validates the FlowConfig.
+func (c *Config) validateFlow() error {
+	if c.Flow.Ratio <= 0 {
+		return errInvalidFlowRatio.Fmt(c.Flow.Ratio)
+	}
+
+	for _, rule := range c.Flow.Rules {
+		if rule.Work < 0 || rule.Break < 0 {
+			return errNegativeFlowRule
+		}
+	}
+
+	return nil
+}
+
+// validateKeys ensures that no key is bound to more than one action in the
+// timer, since only the first matching action would ever run.
+func (c *Config) validateKeys() error {
+	bound := make(map[string]string)
+
+	for _, b := range c.Keys.bindings() {
+		for _, k := range b.keys {
+			if k == "" {
+				return errEmptyKey.Fmt(b.action)
+			}
+
+			other, ok := bound[k]
+			if ok && !canShareKeys(other, b.action) {
+				return errKeyConflict.Fmt(k, other, b.action)
+			}
+
+			bound[k] = b.action
+		}
+	}
+
+	return nil
+}
+
+// validateGit ensures that every git rule has a path and tags to add.
+func (c *Config) validateGit() error {
+	for i, rule := range c.Git.Rules {
+		if strings.TrimSpace(rule.Path) == "" || len(rule.Tags) == 0 {
+			return errInvalidGitRule.Fmt(i + 1)
+		}
+	}
+
+	return nil
+}
+
+// validateTaskSource ensures that the task source can be read from and
+// supports the action taken on completed tasks.
+func (c *Config) validateTaskSource() error {
+	ts := c.TaskSource
+
+	switch ts.Type {
+	case "":
+		if c.PickTaskEnabled() {
+			return errNoTaskSource
+		}
+	case tasksource.TodoTxt:
+		if strings.TrimSpace(ts.File) == "" {
+			return errTodoFileMissing
+		}
+
+		if ts.OnComplete == TaskActionNote {
+			return errTodoNotes
+		}
+	case tasksource.Taskwarrior:
+	default:
+		return errUnknownTaskSource.Fmt(ts.Type)
+	}
+
+	switch ts.OnComplete {
+	case TaskActionNone, TaskActionDone, TaskActionNote:
+	default:
+		return errInvalidTaskAction.Fmt(ts.OnComplete)
+	}
+
+	return nil
+}
+
+// validatePresets validates the presets defined in the config file and the
+// sequence selected with --preset.
+func (c *Config) validatePresets() error {
+	for name, steps := range c.Presets {
+		if err := validateSequence(name, steps); err != nil {
+			return err
+		}
+	}
+
+	if c.CLI.Preset != "" {
+		return validateSequence(c.CLI.Preset, c.CLI.Sequence)
+	}
+
+	return nil
+}
+
+// validateSequence validates the sessions in a preset.
+func validateSequence(name string, steps []SequenceStep) error {
+	if len(steps) == 0 || steps[0].Session != stepWork {
+		return errPresetStart.Fmt(name)
+	}
+
+	for _, step := range steps {
+		if _, ok := stepTypes[step.Session]; !ok {
+			return errInvalidPresetSession.Fmt(name, step.Session)
+		}
+
+		if step.Duration != 0 && (step.Duration < minSessionDuration ||
+			step.Duration > maxSessionDuration) {
+			return errInvalidPresetDuration.Fmt(
+				name,
+				minSessionDuration,
+				maxSessionDuration,
+			)
+		}
+	}
+
+	return nil
+}
+
 // validateSessionRelationships validates logical relationships between sessions.
 func (c *Config) validateSessionRelationships() error {
 	if c.ShortBreak.Duration >= c.Work.Duration {
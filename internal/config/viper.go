@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/viper"
 )
@@ -31,7 +32,41 @@ const (
 	keyAmbientSound         = "settings.ambient_sound"
 	keySessionCmd           = "settings.cmd"
 	keyTwentyFourHour       = "settings.24hr_clock"
+	keyNotesPrompt          = "settings.notes_prompt"
+	keyExtend               = "settings.extend"
+	keySnooze               = "settings.snooze"
+	keyMaxSessionsPerDay    = "settings.max_sessions_per_day"
+	keyShutdownChecklist    = "settings.shutdown_checklist"
+	keyAnnounceInterval     = "settings.announce_interval"
 	keyDarkTheme            = "display.dark_theme"
+	keyDisplayMode          = "display.mode"
+	keyTheme                = "display.theme"
+	keyGoalsUnit            = "goals.unit"
+	keyGoalsDaily           = "goals.daily"
+	keyGoalsWeekly          = "goals.weekly"
+	keyGoalsTags            = "goals.tags"
+	keyFlowRatio            = "flow.ratio"
+	keyFlowRules            = "flow.rules"
+	keyKeysPlayPause        = "keys.play_pause"
+	keyKeysSound            = "keys.sound"
+	keyKeysInternal         = "keys.internal_interruption"
+	keyKeysExternal         = "keys.external_interruption"
+	keyKeysContinue         = "keys.continue"
+	keyKeysFinish           = "keys.finish"
+	keyKeysFinishEarly      = "keys.finish_early"
+	keyKeysExtend           = "keys.extend"
+	keyKeysSnooze           = "keys.snooze"
+	keyKeysDisplay          = "keys.display"
+	keyKeysToday            = "keys.today"
+	keyKeysTags             = "keys.tags"
+	keyKeysSkip             = "keys.skip"
+	keyKeysQuit             = "keys.quit"
+	keyGitAutoTag           = "git.auto_tag"
+	keyGitRules             = "git.rules"
+	keyTaskSourceType       = "task_source.type"
+	keyTaskSourceFile       = "task_source.file"
+	keyTaskSourceOnComplete = "task_source.on_complete"
+	keyTaskSourcePick       = "task_source.pick"
 )
 
 // WithViperConfig returns an Option that loads configuration from Viper.
@@ -66,13 +101,13 @@ func setupViper(v *viper.Viper, c *Config) {
 	// Set defaults
 	v.SetDefault(keyWorkDuration, "25m")
 	v.SetDefault(keyWorkMessage, "Focus on your task")
-	v.SetDefault(keyWorkColor, "#B0DB43")
+	v.SetDefault(keyWorkColor, "")
 	v.SetDefault(keyWorkSound, "loud_bell")
 	v.SetDefault(keyShortBreakDuration, "5m")
 	v.SetDefault(keyShortBreakMessage, "Take a breather")
-	v.SetDefault(keyShortBreakColor, "#12EAEA")
+	v.SetDefault(keyShortBreakColor, "")
 	v.SetDefault(keyShortBreakSound, "bell")
-	v.SetDefault(keyLongBreakColor, "#C492B1")
+	v.SetDefault(keyLongBreakColor, "")
 	v.SetDefault(keyLongBreakMessage, "Take a long break")
 	v.SetDefault(keyLongBreakDuration, "15m")
 	v.SetDefault(keyLongBreakSound, "bell")
@@ -82,10 +117,44 @@ func setupViper(v *viper.Viper, c *Config) {
 	v.SetDefault(keyNotificationsEnabled, true)
 	v.SetDefault(keySoundOnBreak, false)
 	v.SetDefault(keyDarkTheme, true)
+	v.SetDefault(keyDisplayMode, string(DisplayStandard))
+	v.SetDefault(keyTheme, "")
 	v.SetDefault(keyStrict, false)
 	v.SetDefault(keyAmbientSound, "")
 	v.SetDefault(keySessionCmd, "")
 	v.SetDefault(keyTwentyFourHour, true)
+	v.SetDefault(keyNotesPrompt, false)
+	v.SetDefault(keyExtend, "5m")
+	v.SetDefault(keySnooze, "5m")
+	v.SetDefault(keyMaxSessionsPerDay, 0)
+	v.SetDefault(keyShutdownChecklist, []string{})
+	v.SetDefault(keyAnnounceInterval, "5m")
+	v.SetDefault(keyGoalsUnit, string(GoalPomodoros))
+	v.SetDefault(keyGoalsDaily, 0)
+	v.SetDefault(keyGoalsWeekly, 0)
+	v.SetDefault(keyGoalsTags, []string{})
+	v.SetDefault(keyFlowRatio, 5)
+	v.SetDefault(keyFlowRules, []FlowRule{})
+	v.SetDefault(keyKeysPlayPause, []string{"p"})
+	v.SetDefault(keyKeysSound, []string{"s"})
+	v.SetDefault(keyKeysInternal, []string{"i"})
+	v.SetDefault(keyKeysExternal, []string{"e"})
+	v.SetDefault(keyKeysContinue, []string{"enter"})
+	v.SetDefault(keyKeysFinish, []string{"enter"})
+	v.SetDefault(keyKeysFinishEarly, []string{"f"})
+	v.SetDefault(keyKeysExtend, []string{"+", "="})
+	v.SetDefault(keyKeysSnooze, []string{"z"})
+	v.SetDefault(keyKeysDisplay, []string{"d"})
+	v.SetDefault(keyKeysToday, []string{"t"})
+	v.SetDefault(keyKeysTags, []string{"#"})
+	v.SetDefault(keyKeysSkip, []string{"esc"})
+	v.SetDefault(keyKeysQuit, []string{"q"})
+	v.SetDefault(keyGitAutoTag, false)
+	v.SetDefault(keyGitRules, []GitRule{})
+	v.SetDefault(keyTaskSourceType, "")
+	v.SetDefault(keyTaskSourceFile, "")
+	v.SetDefault(keyTaskSourceOnComplete, string(TaskActionNone))
+	v.SetDefault(keyTaskSourcePick, false)
 
 	if c.firstRun {
 		v.SetDefault(
@@ -110,5 +179,12 @@ func setupViper(v *viper.Viper, c *Config) {
 
 // loadViperConfig loads configuration from Viper into the Config struct.
 func loadViperConfig(v *viper.Viper, c *Config) error {
-	return v.Unmarshal(c)
+	err := v.Unmarshal(c)
+	if err != nil {
+		return err
+	}
+
+	c.Theme, err = c.loadTheme(filepath.Dir(v.ConfigFileUsed()))
+
+	return err
 }
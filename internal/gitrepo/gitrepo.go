@@ -0,0 +1,134 @@
+// Package gitrepo detects the git repository and branch of a directory by
+// reading the repository files directly so that git doesn't need to be
+// installed
+package gitrepo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Repo is a git repository.
+type Repo struct {
+	// Root is the top-level directory of the working tree.
+	Root string
+	// Dir is the directory that holds the repository files. It is usually
+	// the `.git` directory in Root, but is elsewhere for linked worktrees and
+	// submodules.
+	Dir string
+	// Branch is the branch that is checked out. It is empty if HEAD is
+	// detached.
+	Branch string
+	// Head is the commit that is checked out if HEAD is detached.
+	Head string
+}
+
+const (
+	dotGit = ".git"
+
+	// gitdirPrefix starts the `.git` file of linked worktrees and submodules.
+	gitdirPrefix = "gitdir:"
+
+	refPrefix    = "ref:"
+	branchPrefix = "refs/heads/"
+)
+
+var (
+	// ErrNotRepo is returned when a directory isn't in a git repository.
+	ErrNotRepo = errors.New("not a git repository")
+
+	errInvalidHead = errors.New("invalid HEAD")
+)
+
+// Find returns the git repository that contains dir.
+func Find(dir string) (Repo, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return Repo{}, err
+	}
+
+	for {
+		repo, err := open(dir)
+		if !errors.Is(err, os.ErrNotExist) {
+			return repo, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Repo{}, ErrNotRepo
+		}
+
+		dir = parent
+	}
+}
+
+// Name returns the name of the repository, which is the name of its root
+// directory.
+func (r Repo) Name() string {
+	return filepath.Base(r.Root)
+}
+
+// open reads the repository whose working tree starts at root.
+func open(root string) (Repo, error) {
+	path := filepath.Join(root, dotGit)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Repo{}, err
+	}
+
+	repo := Repo{
+		Root: root,
+		Dir:  path,
+	}
+
+	if !info.IsDir() {
+		repo.Dir, err = readGitdir(path)
+		if err != nil {
+			return Repo{}, err
+		}
+	}
+
+	head, err := os.ReadFile(filepath.Join(repo.Dir, "HEAD"))
+	if err != nil {
+		return Repo{}, err
+	}
+
+	ref, symbolic := strings.CutPrefix(strings.TrimSpace(string(head)), refPrefix)
+	ref = strings.TrimSpace(ref)
+
+	switch {
+	case symbolic:
+		repo.Branch = strings.TrimPrefix(ref, branchPrefix)
+	case ref != "":
+		repo.Head = ref
+	default:
+		return Repo{}, errInvalidHead
+	}
+
+	return repo, nil
+}
+
+// readGitdir returns the repository directory named in the `.git` file at
+// path. Relative directories are relative to the file.
+func readGitdir(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	dir, ok := strings.CutPrefix(strings.TrimSpace(string(b)), gitdirPrefix)
+	if !ok {
+		return "", ErrNotRepo
+	}
+
+	dir = strings.TrimSpace(dir)
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(filepath.Dir(path), dir)
+	}
+
+	return filepath.Clean(dir), nil
+}
@@ -0,0 +1,167 @@
+package gitrepo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/gitrepo"
+	"github.com/ayoisaiah/focus/internal/testutil"
+)
+
+// writeFile creates the file at path along with its parent directories.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+
+	writeFile(
+		t,
+		filepath.Join(root, "focus", ".git", "HEAD"),
+		"ref: refs/heads/feat/git-tags\n",
+	)
+	writeFile(
+		t,
+		filepath.Join(root, "detached", ".git", "HEAD"),
+		"3e8c9197c2ab1d7e0f5a1c4b0e2d7f6a9b8c1d2e\n",
+	)
+	writeFile(
+		t,
+		filepath.Join(root, "focus", ".git", "worktrees", "hotfix", "HEAD"),
+		"ref: refs/heads/hotfix\n",
+	)
+	writeFile(
+		t,
+		filepath.Join(root, "hotfix", ".git"),
+		"gitdir: ../focus/.git/worktrees/hotfix\n",
+	)
+	writeFile(t, filepath.Join(root, "focus", "timer", "timer.go"), "")
+
+	testCases := []struct {
+		Name string
+		Dir  string
+		Want gitrepo.Repo
+	}{
+		{
+			Name: "branch from the root",
+			Dir:  filepath.Join(root, "focus"),
+			Want: gitrepo.Repo{
+				Root:   filepath.Join(root, "focus"),
+				Dir:    filepath.Join(root, "focus", ".git"),
+				Branch: "feat/git-tags",
+			},
+		},
+		{
+			Name: "branch from a subdirectory",
+			Dir:  filepath.Join(root, "focus", "timer"),
+			Want: gitrepo.Repo{
+				Root:   filepath.Join(root, "focus"),
+				Dir:    filepath.Join(root, "focus", ".git"),
+				Branch: "feat/git-tags",
+			},
+		},
+		{
+			Name: "detached HEAD",
+			Dir:  filepath.Join(root, "detached"),
+			Want: gitrepo.Repo{
+				Root: filepath.Join(root, "detached"),
+				Dir:  filepath.Join(root, "detached", ".git"),
+				Head: "3e8c9197c2ab1d7e0f5a1c4b0e2d7f6a9b8c1d2e",
+			},
+		},
+		{
+			Name: "linked worktree",
+			Dir:  filepath.Join(root, "hotfix"),
+			Want: gitrepo.Repo{
+				Root:   filepath.Join(root, "hotfix"),
+				Dir:    filepath.Join(root, "focus", ".git", "worktrees", "hotfix"),
+				Branch: "hotfix",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got, err := gitrepo.Find(tc.Dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
+
+func TestFindNotRepo(t *testing.T) {
+	_, err := gitrepo.Find(t.TempDir())
+
+	assert.ErrorIs(t, err, gitrepo.ErrNotRepo)
+}
+
+func TestLog(t *testing.T) {
+	start := time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)
+
+	dir, hashes := testutil.GitRepo(t, []testutil.GitCommit{
+		{Name: "initial", Author: "Jane", Subject: "Initial commit", Time: start},
+		{
+			Name:    "api",
+			Author:  "Jane",
+			Subject: "Add the sessions API",
+			Time:    start.Add(time.Hour),
+			Parents: []string{"initial"},
+		},
+		{
+			Name:    "docs",
+			Author:  "John",
+			Subject: "Document the API",
+			Time:    start.Add(2 * time.Hour),
+			Parents: []string{"initial"},
+		},
+		{
+			Name:    "merge",
+			Author:  "Jane",
+			Subject: "Merge branch 'docs'",
+			Time:    start.Add(3 * time.Hour),
+			Parents: []string{"api", "docs"},
+		},
+	})
+
+	repo, err := gitrepo.Find(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := repo.Log(start.Add(30 * time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make([]string, 0, len(commits))
+
+	for _, c := range commits {
+		got = append(got, c.Hash)
+	}
+
+	assert.Equal(t, []string{hashes["merge"], hashes["docs"], hashes["api"]}, got)
+
+	docs := commits[1]
+
+	assert.Equal(t, "John", docs.Author)
+	assert.Equal(t, "john@example.com", docs.Email)
+	assert.Equal(t, "Document the API", docs.Subject)
+	assert.True(t, start.Add(2*time.Hour).Equal(docs.AuthorTime))
+	assert.Equal(t, []string{hashes["initial"]}, docs.Parents)
+}
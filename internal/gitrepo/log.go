@@ -0,0 +1,201 @@
+package gitrepo
+
+import (
+	"bytes"
+	"cmp"
+	"container/heap"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit is a commit in the history of a repository.
+type Commit struct {
+	// AuthorTime is when the changes were originally committed. Unlike the
+	// commit time, it is kept when commits are rebased or cherry-picked.
+	AuthorTime time.Time `json:"author_time"`
+	// CommitTime is when the commit was last written.
+	CommitTime time.Time `json:"-"`
+	Hash       string    `json:"hash"`
+	Author     string    `json:"author"`
+	Email      string    `json:"-"`
+	Subject    string    `json:"subject"`
+	Parents    []string  `json:"-"`
+}
+
+var errInvalidCommit = errors.New("invalid commit")
+
+// Log returns the commits reachable from HEAD that were committed at or after
+// since, from the most recent. Like `git log`, the history is walked in commit
+// time order and stops at the first commit older than since, so commits with
+// a clock skew might be missed.
+func (r Repo) Log(since time.Time) ([]Commit, error) {
+	head, err := r.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := r.openObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.close()
+
+	var (
+		commits []Commit
+		queue   commitQueue
+	)
+
+	seen := make(map[string]bool)
+
+	push := func(hash string) error {
+		if seen[hash] {
+			return nil
+		}
+
+		seen[hash] = true
+
+		c, err := s.readCommit(hash)
+		if err != nil {
+			return err
+		}
+
+		heap.Push(&queue, c)
+
+		return nil
+	}
+
+	if err := push(head); err != nil {
+		return nil, err
+	}
+
+	for queue.Len() > 0 {
+		c, _ := heap.Pop(&queue).(Commit)
+		if c.CommitTime.Before(since) {
+			break
+		}
+
+		commits = append(commits, c)
+
+		for _, parent := range c.Parents {
+			if err := push(parent); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return commits, nil
+}
+
+// readCommit reads and parses the commit with the specified hash.
+func (s *objectStore) readCommit(hash string) (Commit, error) {
+	kind, data, err := s.read(hash)
+	if err != nil {
+		return Commit{}, err
+	}
+
+	if kind != "commit" {
+		return Commit{}, fmt.Errorf("%w: %s is a %s", errInvalidCommit, hash, kind)
+	}
+
+	c, err := parseCommit(data)
+	if err != nil {
+		return Commit{}, fmt.Errorf("%w: %s", err, hash)
+	}
+
+	c.Hash = hash
+
+	return c, nil
+}
+
+// parseCommit parses the headers and message of a commit object.
+func parseCommit(data []byte) (Commit, error) {
+	var c Commit
+
+	headers, message, _ := bytes.Cut(data, []byte("\n\n"))
+
+	for line := range strings.Lines(string(headers)) {
+		key, value, _ := strings.Cut(strings.TrimSuffix(line, "\n"), " ")
+
+		switch key {
+		case "parent":
+			c.Parents = append(c.Parents, value)
+		case "author":
+			name, email, t, err := parseSignature(value)
+			if err != nil {
+				return c, err
+			}
+
+			c.Author, c.Email, c.AuthorTime = name, email, t
+		case "committer":
+			_, _, t, err := parseSignature(value)
+			if err != nil {
+				return c, err
+			}
+
+			c.CommitTime = t
+		}
+	}
+
+	subject, _, _ := strings.Cut(strings.TrimSpace(string(message)), "\n")
+	c.Subject = subject
+
+	return c, nil
+}
+
+// parseSignature parses the author or committer of a commit, such as
+// `Jane Doe <jane@example.com> 1700000000 +0100`.
+func parseSignature(s string) (name, email string, t time.Time, err error) {
+	start := strings.LastIndex(s, "<")
+	end := strings.LastIndex(s, ">")
+
+	if start < 0 || end < start {
+		return "", "", t, errInvalidCommit
+	}
+
+	name = strings.TrimSpace(s[:start])
+	email = s[start+1 : end]
+
+	fields := strings.Fields(s[end+1:])
+	if len(fields) != 2 {
+		return "", "", t, errInvalidCommit
+	}
+
+	secs, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return "", "", t, errInvalidCommit
+	}
+
+	return name, email, time.Unix(secs, 0), nil
+}
+
+// commitQueue orders commits from the most recently committed.
+type commitQueue []Commit
+
+func (q commitQueue) Len() int {
+	return len(q)
+}
+
+func (q commitQueue) Less(i, j int) bool {
+	return cmp.Compare(q[i].CommitTime.Unix(), q[j].CommitTime.Unix()) > 0
+}
+
+func (q commitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *commitQueue) Push(x any) {
+	c, _ := x.(Commit)
+	*q = append(*q, c)
+}
+
+func (q *commitQueue) Pop() any {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return c
+}
@@ -0,0 +1,130 @@
+package gitrepo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errObjectNotFound = errors.New("object not found")
+
+// objectStore reads objects from the loose object directory and the
+// packfiles of a repository.
+type objectStore struct {
+	dir   string
+	packs []*pack
+}
+
+// openObjects opens the object store of the repository.
+func (r Repo) openObjects() (*objectStore, error) {
+	dir := filepath.Join(r.commonDir(), "objects")
+
+	packs, err := openPacks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &objectStore{dir: dir, packs: packs}, nil
+}
+
+// close closes the packfiles of the store.
+func (s *objectStore) close() {
+	closePacks(s.packs)
+}
+
+// read returns the type and contents of the object with the specified hash.
+func (s *objectStore) read(hash string) (string, []byte, error) {
+	raw, err := hex.DecodeString(hash)
+	if err != nil || len(raw) != hashSize {
+		return "", nil, fmt.Errorf("%w: %s", errObjectNotFound, hash)
+	}
+
+	kind, data, err := s.readLoose(hash)
+	if !errors.Is(err, os.ErrNotExist) {
+		return kind, data, err
+	}
+
+	for _, p := range s.packs {
+		if offset, ok := p.find(raw); ok {
+			return p.read(s, offset)
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: %s", errObjectNotFound, hash)
+}
+
+// readLoose reads an object that is stored in its own file.
+func (s *objectStore) readLoose(hash string) (string, []byte, error) {
+	f, err := os.Open(filepath.Join(s.dir, hash[:2], hash[2:]))
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer f.Close()
+
+	b, err := inflate(bufio.NewReader(f))
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the contents follow a header such as "commit 240\x00"
+	header, data, ok := bytes.Cut(b, []byte{0})
+	if !ok {
+		return "", nil, errInvalidPack
+	}
+
+	kind, _, _ := strings.Cut(string(header), " ")
+
+	return kind, data, nil
+}
+
+// commonDir returns the directory that holds the objects and refs shared by
+// every worktree of the repository.
+func (r Repo) commonDir() string {
+	b, err := os.ReadFile(filepath.Join(r.Dir, "commondir"))
+	if err != nil {
+		return r.Dir
+	}
+
+	dir := strings.TrimSpace(string(b))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.Dir, dir)
+	}
+
+	return filepath.Clean(dir)
+}
+
+// resolve returns the commit that HEAD points to.
+func (r Repo) resolve() (string, error) {
+	if r.Branch == "" {
+		return r.Head, nil
+	}
+
+	ref := branchPrefix + r.Branch
+
+	for _, dir := range []string{r.Dir, r.commonDir()} {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(ref)))
+		if err == nil {
+			return strings.TrimSpace(string(b)), nil
+		}
+	}
+
+	b, err := os.ReadFile(filepath.Join(r.commonDir(), "packed-refs"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	for line := range strings.Lines(string(b)) {
+		hash, name, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if ok && name == ref {
+			return hash, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s has no commits", errInvalidHead, r.Branch)
+}
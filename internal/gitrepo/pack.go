@@ -0,0 +1,357 @@
+package gitrepo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Object types as they are numbered in packfiles.
+const (
+	packCommit   = 1
+	packTree     = 2
+	packBlob     = 3
+	packTag      = 4
+	packOfsDelta = 6
+	packRefDelta = 7
+)
+
+const (
+	hashSize = 20
+
+	// idxMagic starts version 2 and later pack index files.
+	idxMagic   = "\xfftOc"
+	idxVersion = 2
+
+	// largeOffsetFlag marks offsets that are stored in the table of 64-bit
+	// offsets.
+	largeOffsetFlag = 0x80000000
+)
+
+var (
+	errInvalidIndex = errors.New("invalid pack index")
+	errInvalidPack  = errors.New("invalid packfile")
+	errInvalidDelta = errors.New("invalid delta")
+)
+
+var packTypes = map[byte]string{
+	packCommit: "commit",
+	packTree:   "tree",
+	packBlob:   "blob",
+	packTag:    "tag",
+}
+
+// pack is a packfile along with the contents of its index.
+type pack struct {
+	file    *os.File
+	fanout  [256]uint32
+	hashes  []byte
+	offsets []uint64
+}
+
+// openPacks opens every packfile in the objects directory.
+func openPacks(objectsDir string) ([]*pack, error) {
+	indexes, err := filepath.Glob(filepath.Join(objectsDir, "pack", "*.idx"))
+	if err != nil {
+		return nil, err
+	}
+
+	packs := make([]*pack, 0, len(indexes))
+
+	for _, idx := range indexes {
+		p, err := openPack(idx)
+		if err != nil {
+			closePacks(packs)
+
+			return nil, fmt.Errorf("%s: %w", filepath.Base(idx), err)
+		}
+
+		packs = append(packs, p)
+	}
+
+	return packs, nil
+}
+
+// closePacks closes the packfiles.
+func closePacks(packs []*pack) {
+	for _, p := range packs {
+		_ = p.file.Close()
+	}
+}
+
+// openPack reads the version 2 index at path and opens its packfile.
+func openPack(path string) (*pack, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	header := len(idxMagic) + 4 + 256*4
+
+	if len(b) < header || string(b[:len(idxMagic)]) != idxMagic ||
+		binary.BigEndian.Uint32(b[4:8]) != idxVersion {
+		return nil, errInvalidIndex
+	}
+
+	p := &pack{}
+
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(b[8+i*4:])
+	}
+
+	n := int(p.fanout[255])
+
+	// hashes, then CRCs, then 32-bit offsets, then 64-bit offsets
+	hashesStart := header
+	offsetsStart := hashesStart + n*hashSize + n*4
+	largeStart := offsetsStart + n*4
+
+	if len(b) < largeStart {
+		return nil, errInvalidIndex
+	}
+
+	p.hashes = b[hashesStart : hashesStart+n*hashSize]
+	p.offsets = make([]uint64, n)
+
+	for i := range n {
+		offset := binary.BigEndian.Uint32(b[offsetsStart+i*4:])
+		if offset&largeOffsetFlag == 0 {
+			p.offsets[i] = uint64(offset)
+
+			continue
+		}
+
+		j := largeStart + int(offset&^largeOffsetFlag)*8
+		if len(b) < j+8 {
+			return nil, errInvalidIndex
+		}
+
+		p.offsets[i] = binary.BigEndian.Uint64(b[j:])
+	}
+
+	p.file, err = os.Open(strings.TrimSuffix(path, ".idx") + ".pack")
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// find returns the offset of the object in the packfile.
+func (p *pack) find(hash []byte) (uint64, bool) {
+	lo, hi := 0, int(p.fanout[hash[0]])
+	if hash[0] > 0 {
+		lo = int(p.fanout[hash[0]-1])
+	}
+
+	for lo < hi {
+		mid := (lo + hi) / 2
+
+		switch bytes.Compare(p.hashes[mid*hashSize:(mid+1)*hashSize], hash) {
+		case 0:
+			return p.offsets[mid], true
+		case -1:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+
+	return 0, false
+}
+
+// read returns the type and contents of the object at offset. Deltified
+// objects are resolved against their base, which may be in another pack or
+// loose if it is referred to by hash.
+func (p *pack) read(s *objectStore, offset uint64) (string, []byte, error) {
+	r := bufio.NewReader(io.NewSectionReader(p.file, int64(offset), 1<<62)) //nolint:gosec // offsets fit in int64
+
+	b, err := r.ReadByte()
+	if err != nil {
+		return "", nil, err
+	}
+
+	kind := (b >> 4) & 7
+
+	// the size is only needed to check the contents
+	size := uint64(b & 0x0f)
+
+	for shift := 4; b&0x80 != 0; shift += 7 {
+		b, err = r.ReadByte()
+		if err != nil {
+			return "", nil, err
+		}
+
+		size |= uint64(b&0x7f) << shift
+	}
+
+	var (
+		baseKind string
+		base     []byte
+	)
+
+	switch kind {
+	case packOfsDelta:
+		rel, err := readOfsDeltaOffset(r)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if rel > offset {
+			return "", nil, errInvalidPack
+		}
+
+		baseKind, base, err = p.read(s, offset-rel)
+		if err != nil {
+			return "", nil, err
+		}
+	case packRefDelta:
+		hash := make([]byte, hashSize)
+
+		if _, err := io.ReadFull(r, hash); err != nil {
+			return "", nil, err
+		}
+
+		baseKind, base, err = s.read(hex.EncodeToString(hash))
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	data, err := inflate(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if uint64(len(data)) != size {
+		return "", nil, errInvalidPack
+	}
+
+	if base != nil {
+		data, err = applyDelta(base, data)
+
+		return baseKind, data, err
+	}
+
+	name, ok := packTypes[kind]
+	if !ok {
+		return "", nil, errInvalidPack
+	}
+
+	return name, data, nil
+}
+
+// readOfsDeltaOffset reads the distance back from a delta to its base.
+func readOfsDeltaOffset(r io.ByteReader) (uint64, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+
+	offset := uint64(b & 0x7f)
+
+	for b&0x80 != 0 {
+		b, err = r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+
+		offset = ((offset + 1) << 7) | uint64(b&0x7f)
+	}
+
+	return offset, nil
+}
+
+// inflate decompresses the zlib stream at the start of r.
+func inflate(r io.Reader) ([]byte, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer zr.Close()
+
+	return io.ReadAll(zr)
+}
+
+// applyDelta rebuilds an object from its base and a delta, which is a list of
+// instructions to copy ranges of the base or insert new data.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	r := bytes.NewReader(delta)
+
+	baseSize, err := binary.ReadUvarint(r)
+	if err != nil || baseSize != uint64(len(base)) {
+		return nil, errInvalidDelta
+	}
+
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, errInvalidDelta
+	}
+
+	result := make([]byte, 0, size)
+
+	for {
+		op, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		switch {
+		case op&0x80 != 0:
+			var offset, n uint64
+
+			// the low bits say which bytes of the offset and size follow
+			for i := range 7 {
+				if op&(1<<i) == 0 {
+					continue
+				}
+
+				b, err := r.ReadByte()
+				if err != nil {
+					return nil, errInvalidDelta
+				}
+
+				if i < 4 {
+					offset |= uint64(b) << (8 * i)
+				} else {
+					n |= uint64(b) << (8 * (i - 4))
+				}
+			}
+
+			if n == 0 {
+				n = 0x10000
+			}
+
+			if offset+n > uint64(len(base)) {
+				return nil, errInvalidDelta
+			}
+
+			result = append(result, base[offset:offset+n]...)
+		case op != 0:
+			data := make([]byte, op)
+
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, errInvalidDelta
+			}
+
+			result = append(result, data...)
+		default:
+			return nil, errInvalidDelta
+		}
+	}
+
+	if uint64(len(result)) != size {
+		return nil, errInvalidDelta
+	}
+
+	return result, nil
+}
@@ -0,0 +1,75 @@
+// Package lockfile holds an exclusive lock on a file for the life of a process
+// so that other processes can tell whether it is still running. The lock is
+// released by the operating system if the process exits without releasing it
+package lockfile
+
+import (
+	"errors"
+	"os"
+	"strconv"
+)
+
+// ErrLocked is returned when the file is locked by another process.
+var ErrLocked = errors.New("file is locked by another process")
+
+// Lock is an exclusive lock on a file.
+type Lock struct {
+	file *os.File
+}
+
+// Acquire locks the file at path, creating it if it doesn't exist, and writes
+// the ID of the current process to it. It returns ErrLocked without waiting if
+// the file is already locked.
+func Acquire(path string) (*Lock, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	err = lock(f)
+	if err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	// the process ID is only informational, so failing to write it is not
+	// an error
+	if f.Truncate(0) == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+
+	return &Lock{file: f}, nil
+}
+
+// Release unlocks the file. The file is left in place so that it can be
+// locked again.
+func (l *Lock) Release() error {
+	err := unlock(l.file)
+
+	cerr := l.file.Close()
+	if err == nil {
+		err = cerr
+	}
+
+	return err
+}
+
+// Held reports whether the file at path is locked by a running process.
+func Held(path string) bool {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+
+	defer f.Close()
+
+	err = lock(f)
+	if err != nil {
+		return errors.Is(err, ErrLocked)
+	}
+
+	_ = unlock(f)
+
+	return false
+}
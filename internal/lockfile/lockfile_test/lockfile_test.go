@@ -0,0 +1,43 @@
+package lockfile_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/lockfile"
+)
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "focus.lock")
+
+	assert.False(t, lockfile.Held(path), "missing file should not be held")
+
+	lock, err := lockfile.Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, lockfile.Held(path))
+
+	_, err = lockfile.Acquire(path)
+	if !errors.Is(err, lockfile.ErrLocked) {
+		t.Fatalf("expected ErrLocked, got: %v", err)
+	}
+
+	err = lock.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, lockfile.Held(path), "released file should not be held")
+
+	lock, err = lockfile.Acquire(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = lock.Release()
+}
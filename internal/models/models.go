@@ -6,6 +6,23 @@ import (
 	"github.com/ayoisaiah/focus/internal/config"
 )
 
+// InterruptionKind distinguishes interruptions that originate from the person
+// working (internal) from those caused by someone or something else
+// (external).
+type InterruptionKind string
+
+const (
+	InternalInterruption InterruptionKind = "internal"
+	ExternalInterruption InterruptionKind = "external"
+)
+
+// Interruption is a distraction logged during a work session.
+type Interruption struct {
+	Time   time.Time        `json:"time"`
+	Kind   InterruptionKind `json:"kind"`
+	Reason string           `json:"reason,omitempty"`
+}
+
 type SessionTimeline struct {
 	// StartTime is the start of the session including
 	// the start of a paused session
@@ -16,11 +33,30 @@ type SessionTimeline struct {
 }
 
 type Session struct {
-	StartTime time.Time          `json:"start_time"`
-	EndTime   time.Time          `json:"end_time"`
-	Name      config.SessionType `json:"name"`
-	Tags      []string           `json:"tags"`
-	Timeline  []SessionTimeline  `json:"timeline"`
-	Duration  time.Duration      `json:"duration"`
-	Completed bool               `json:"completed"`
+	StartTime     time.Time          `json:"start_time"`
+	EndTime       time.Time          `json:"end_time"`
+	Name          config.SessionType `json:"name"`
+	Tags          []string           `json:"tags"`
+	Notes         string             `json:"notes,omitempty"`
+	Timeline      []SessionTimeline  `json:"timeline"`
+	Interruptions []Interruption     `json:"interruptions,omitempty"`
+	Duration      time.Duration      `json:"duration"`
+	TaskID        int                `json:"task_id,omitempty"`
+	Completed     bool               `json:"completed"`
+}
+
+// Task is a unit of work that sessions can be attached to. The estimate is
+// measured in pomodoros (completed work sessions).
+type Task struct {
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	Title       string    `json:"title"`
+	Tags        []string  `json:"tags"`
+	ID          int       `json:"id"`
+	Estimate    int       `json:"estimate"`
+}
+
+// Done reports whether the task has been marked as done.
+func (t *Task) Done() bool {
+	return !t.CompletedAt.IsZero()
 }
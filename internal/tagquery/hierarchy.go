@@ -0,0 +1,43 @@
+package tagquery
+
+import "strings"
+
+// separators are the characters that separate the levels of a hierarchical
+// tag such as `acme/api/auth` or `client:acme`.
+const separators = "/:"
+
+// Parent returns the parent of a hierarchical tag (e.g. `acme/api` for
+// `acme/api/auth`). It returns an empty string for a top-level tag.
+func Parent(tag string) string {
+	i := strings.LastIndexAny(tag, separators)
+	if i < 0 {
+		return ""
+	}
+
+	return tag[:i]
+}
+
+// Lineage returns every ancestor of a hierarchical tag from the top-level tag
+// down, followed by the tag itself.
+func Lineage(tag string) []string {
+	var lineage []string
+
+	for i, r := range tag {
+		if i > 0 && strings.ContainsRune(separators, r) {
+			lineage = append(lineage, tag[:i])
+		}
+	}
+
+	return append(lineage, tag)
+}
+
+// Within reports whether tag is the same as ancestor or one of its
+// descendants. `acme/api` is within `acme`, but `acmecorp` is not.
+func Within(tag, ancestor string) bool {
+	rest, ok := strings.CutPrefix(tag, ancestor)
+	if !ok {
+		return false
+	}
+
+	return rest == "" || strings.ContainsRune(separators, rune(rest[0]))
+}
@@ -0,0 +1,262 @@
+// Package tagquery parses and evaluates queries used to filter sessions by
+// their tags, and navigates hierarchical tags such as `acme/api/auth`
+package tagquery
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type (
+	// Query is a parsed tag query such as `billing AND NOT meeting`. A nil
+	// Query matches every session.
+	Query struct {
+		root node
+		raw  string
+	}
+
+	node interface {
+		match(tags []string) bool
+	}
+
+	tagNode struct {
+		name   string
+		prefix bool
+	}
+
+	notNode struct {
+		operand node
+	}
+
+	andNode struct {
+		left, right node
+	}
+
+	orNode struct {
+		left, right node
+	}
+
+	parser struct {
+		tokens []string
+		pos    int
+	}
+)
+
+const (
+	tokenAnd    = "AND"
+	tokenOr     = "OR"
+	tokenNot    = "NOT"
+	tokenComma  = ","
+	tokenLParen = "("
+	tokenRParen = ")"
+	wildcard    = "*"
+)
+
+var (
+	errEmptyExpr = errors.New("expected a tag")
+
+	errUnbalanced = errors.New("unbalanced parentheses")
+)
+
+func (n *tagNode) match(tags []string) bool {
+	for _, t := range tags {
+		if n.prefix && strings.HasPrefix(t, n.name) {
+			return true
+		}
+
+		if !n.prefix && Within(t, n.name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *notNode) match(tags []string) bool {
+	return !n.operand.match(tags)
+}
+
+func (n *andNode) match(tags []string) bool {
+	return n.left.match(tags) && n.right.match(tags)
+}
+
+func (n *orNode) match(tags []string) bool {
+	return n.left.match(tags) || n.right.match(tags)
+}
+
+// tokenize splits a query into tags, operators, and parentheses.
+func tokenize(s string) []string {
+	var (
+		tokens []string
+		cur    strings.Builder
+	)
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r == '(' || r == ')' || r == ',':
+			flush()
+
+			tokens = append(tokens, string(r))
+		default:
+			cur.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	tok := p.peek()
+	p.pos++
+
+	return tok
+}
+
+// parseOr parses: and { ("OR" | ",") and }.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == tokenOr || p.peek() == tokenComma {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orNode{left, right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses: unary { "AND" unary }.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == tokenAnd {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andNode{left, right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses: "NOT" unary | "(" or ")" | tag.
+func (p *parser) parseUnary() (node, error) {
+	tok := p.next()
+
+	switch tok {
+	case "", tokenAnd, tokenOr, tokenComma, tokenRParen:
+		return nil, errEmptyExpr
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{operand}, nil
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.next() != tokenRParen {
+			return nil, errUnbalanced
+		}
+
+		return n, nil
+	}
+
+	if name, ok := strings.CutSuffix(tok, wildcard); ok {
+		return &tagNode{name: name, prefix: true}, nil
+	}
+
+	return &tagNode{name: tok}, nil
+}
+
+// Parse parses a tag query. Tags can be combined with the AND, OR, and NOT
+// operators and grouped with parentheses. A comma is equivalent to OR, so a
+// comma-separated list of tags matches sessions that have any of them. A tag
+// matches its descendants in the tag hierarchy, so `acme` matches
+// `acme/api/auth`. A tag ending in `*` matches every tag that begins with the
+// preceding text (e.g. `client:*`). An empty query returns a nil Query which
+// matches everything.
+func Parse(s string) (*Query, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil //nolint:nilnil // a nil query matches every session
+	}
+
+	p := &parser{tokens: tokenize(s)}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag query %q: %w", s, err)
+	}
+
+	if p.pos < len(p.tokens) {
+		if p.peek() == tokenRParen {
+			return nil, fmt.Errorf("invalid tag query %q: %w", s, errUnbalanced)
+		}
+
+		return nil, fmt.Errorf(
+			"invalid tag query %q: expected AND or OR before %q",
+			s,
+			p.peek(),
+		)
+	}
+
+	return &Query{root: root, raw: s}, nil
+}
+
+// Match reports whether a session with the specified tags satisfies the query.
+func (q *Query) Match(tags []string) bool {
+	if q == nil {
+		return true
+	}
+
+	return q.root.match(tags)
+}
+
+// String returns the query as it was originally written.
+func (q *Query) String() string {
+	if q == nil {
+		return ""
+	}
+
+	return q.raw
+}
@@ -0,0 +1,133 @@
+package tagquery_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/tagquery"
+)
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Query string
+		Tags  []string
+		Want  bool
+	}{
+		{
+			Name:  "empty query matches untagged session",
+			Query: "",
+			Tags:  nil,
+			Want:  true,
+		},
+		{
+			Name:  "single tag",
+			Query: "billing",
+			Tags:  []string{"billing", "meeting"},
+			Want:  true,
+		},
+		{
+			Name:  "comma is OR",
+			Query: "client,piano",
+			Tags:  []string{"piano"},
+			Want:  true,
+		},
+		{
+			Name:  "AND requires both tags",
+			Query: "billing AND meeting",
+			Tags:  []string{"billing"},
+			Want:  false,
+		},
+		{
+			Name:  "AND NOT excludes tag",
+			Query: "billing AND NOT meeting",
+			Tags:  []string{"billing", "meeting"},
+			Want:  false,
+		},
+		{
+			Name:  "AND binds tighter than OR",
+			Query: "writing OR billing AND meeting",
+			Tags:  []string{"writing"},
+			Want:  true,
+		},
+		{
+			Name:  "parentheses group terms",
+			Query: "(writing OR billing) AND meeting",
+			Tags:  []string{"writing"},
+			Want:  false,
+		},
+		{
+			Name:  "tag matches descendants",
+			Query: "acme",
+			Tags:  []string{"acme/api/auth"},
+			Want:  true,
+		},
+		{
+			Name:  "tag does not match other tags with the same prefix",
+			Query: "acme",
+			Tags:  []string{"acmecorp"},
+			Want:  false,
+		},
+		{
+			Name:  "prefix wildcard",
+			Query: "client:*",
+			Tags:  []string{"client:acme"},
+			Want:  true,
+		},
+		{
+			Name:  "prefix wildcard without match",
+			Query: "NOT client:*",
+			Tags:  []string{"client:acme"},
+			Want:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			q, err := tagquery.Parse(tc.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.Want, q.Match(tc.Tags))
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	testCases := []string{
+		"billing AND",
+		"(billing OR meeting",
+		"billing)",
+		"billing meeting",
+		"NOT",
+		",billing",
+	}
+
+	for _, query := range testCases {
+		t.Run(query, func(t *testing.T) {
+			_, err := tagquery.Parse(query)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	assert.Equal(t, "acme/api", tagquery.Parent("acme/api/auth"))
+	assert.Equal(t, "client", tagquery.Parent("client:acme"))
+	assert.Empty(t, tagquery.Parent("acme"))
+
+	assert.Equal(
+		t,
+		[]string{"acme", "acme/api", "acme/api/auth"},
+		tagquery.Lineage("acme/api/auth"),
+	)
+	assert.Equal(t, []string{"acme"}, tagquery.Lineage("acme"))
+
+	assert.True(t, tagquery.Within("acme/api", "acme"))
+	assert.True(t, tagquery.Within("acme", "acme"))
+	assert.False(t, tagquery.Within("acmecorp", "acme"))
+	assert.False(t, tagquery.Within("acme", "acme/api"))
+}
@@ -0,0 +1,84 @@
+// Package tasksource reads open tasks from the task lists kept by other tools,
+// and marks them as done or adds notes to them as they are worked on
+package tasksource
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TodoTxt is a file in the todo.txt format.
+	TodoTxt = "todotxt"
+	// Taskwarrior is the task list of the `task` command.
+	Taskwarrior = "taskwarrior"
+)
+
+const (
+	projectTagPrefix = "project:"
+	contextTagPrefix = "context:"
+)
+
+var (
+	errUnknownSource = errors.New("unknown task source")
+
+	// ErrNotesUnsupported is returned when a note is added to a task in a
+	// list that has nowhere to keep it.
+	ErrNotesUnsupported = errors.New("task source does not support notes")
+)
+
+type (
+	// Task is an open task in a task list. ID is the line number of the task
+	// in a todo.txt file or the UUID of a Taskwarrior task.
+	Task struct {
+		ID          string
+		Description string
+		Priority    string
+		Projects    []string
+		Contexts    []string
+		// Labels are the Taskwarrior tags of the task.
+		Labels []string
+		// line is the todo.txt line that the task was read from.
+		line string
+	}
+
+	// Source is a task list that tasks can be picked from.
+	Source interface {
+		// Tasks returns the open tasks, from the most important
+		Tasks() ([]Task, error)
+		// Done marks the task as done
+		Done(task Task) error
+		// Annotate adds a note to the task
+		Annotate(task Task, note string) error
+	}
+)
+
+// New returns the task source of the specified kind. File is the path of the
+// todo.txt file and is not used by other sources.
+func New(kind, file string) (Source, error) {
+	switch kind {
+	case TodoTxt:
+		return &todoTxt{path: file}, nil
+	case Taskwarrior:
+		return &taskwarrior{cmd: taskwarriorCmd}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnknownSource, kind)
+}
+
+// Tags returns the tags for sessions worked on the task: `project:<name>`
+// for each project, `context:<name>` for each context, and the Taskwarrior
+// tags as they are.
+func (t Task) Tags() []string {
+	tags := make([]string, 0, len(t.Projects)+len(t.Contexts)+len(t.Labels))
+
+	for _, p := range t.Projects {
+		tags = append(tags, projectTagPrefix+p)
+	}
+
+	for _, c := range t.Contexts {
+		tags = append(tags, contextTagPrefix+c)
+	}
+
+	return append(tags, t.Labels...)
+}
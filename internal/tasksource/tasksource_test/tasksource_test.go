@@ -0,0 +1,188 @@
+package tasksource_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/tasksource"
+)
+
+const todoFile = `(B) 2024-01-02 Review pull requests +focus @laptop
+x 2024-01-03 2024-01-01 Renew passport @errands
+
+Call Mom +family @phone
+(A) Write the release notes +focus +docs @laptop due:2024-01-10
+`
+
+// writeTodo creates a todo.txt file with the specified contents.
+func writeTodo(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "todo.txt")
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestTodoTxtTasks(t *testing.T) {
+	source, err := tasksource.New(tasksource.TodoTxt, writeTodo(t, todoFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := source.Tasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var descriptions []string
+
+	for _, task := range tasks {
+		descriptions = append(descriptions, task.Description)
+	}
+
+	assert.Equal(t, []string{
+		"Write the release notes due:2024-01-10",
+		"Review pull requests",
+		"Call Mom",
+	}, descriptions)
+
+	assert.Equal(t, "5", tasks[0].ID)
+	assert.Equal(t, "A", tasks[0].Priority)
+	assert.Equal(
+		t,
+		[]string{"project:focus", "project:docs", "context:laptop"},
+		tasks[0].Tags(),
+	)
+	assert.Equal(t, []string{"project:family", "context:phone"}, tasks[2].Tags())
+}
+
+func TestTodoTxtDone(t *testing.T) {
+	path := writeTodo(t, todoFile)
+
+	source, err := tasksource.New(tasksource.TodoTxt, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := source.Tasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the task is still found after the lines above it are removed
+	err = os.WriteFile(path, []byte(todoFile[strings.Index(todoFile, "Call"):]), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := source.Done(tasks[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	today := time.Now().Format("2006-01-02")
+
+	assert.Equal(
+		t,
+		"Call Mom +family @phone\nx "+today+
+			" Write the release notes +focus +docs @laptop due:2024-01-10\n",
+		string(b),
+	)
+
+	assert.Error(t, source.Done(tasks[1]))
+	assert.ErrorIs(t, source.Annotate(tasks[0], "notes"), tasksource.ErrNotesUnsupported)
+}
+
+func TestTaskwarrior(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake task command is a shell script")
+	}
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "args")
+
+	// the fake command records its arguments and prints an export
+	script := `#!/bin/sh
+echo "$@" >> ` + log + `
+case "$*" in
+*export*) cat <<'JSON'
+[
+  {"uuid": "a1", "description": "Prune roses", "project": "Home.Garden",
+   "status": "pending", "urgency": 2.5},
+  {"uuid": "b2", "description": "File taxes", "tags": ["money"],
+   "priority": "H", "status": "pending", "urgency": 9.1},
+  {"uuid": "c3", "description": "Wait for parcel", "status": "waiting",
+   "urgency": 12}
+]
+JSON
+;;
+esac
+`
+
+	if err := os.WriteFile(filepath.Join(dir, "task"), []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	source, err := tasksource.New(tasksource.Taskwarrior, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tasks, err := source.Tasks()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []tasksource.Task{
+		{
+			ID:          "b2",
+			Description: "File taxes",
+			Priority:    "H",
+			Labels:      []string{"money"},
+		},
+		{
+			ID:          "a1",
+			Description: "Prune roses",
+			Projects:    []string{"Home/Garden"},
+		},
+	}, tasks)
+
+	assert.Equal(t, []string{"project:Home/Garden"}, tasks[1].Tags())
+
+	if err := source.Done(tasks[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := source.Annotate(tasks[1], "Focused for 25 minutes"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(
+		t,
+		"rc.confirmation=off rc.verbose=nothing status:pending export\n"+
+			"rc.confirmation=off rc.verbose=nothing b2 done\n"+
+			"rc.confirmation=off rc.verbose=nothing a1 annotate -- Focused for 25 minutes\n",
+		string(b),
+	)
+}
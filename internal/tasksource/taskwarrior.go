@@ -0,0 +1,115 @@
+package tasksource
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// taskwarriorCmd is the command line interface of Taskwarrior.
+const taskwarriorCmd = "task"
+
+// taskwarriorPending is the status of tasks that are not done or deleted.
+const taskwarriorPending = "pending"
+
+// taskwarrior reads tasks from the JSON export of Taskwarrior, and updates
+// them through its command line interface.
+type taskwarrior struct {
+	cmd string
+}
+
+// taskwarriorTask is a task in the output of `task export`.
+type taskwarriorTask struct {
+	UUID        string   `json:"uuid"`
+	Description string   `json:"description"`
+	Project     string   `json:"project"`
+	Priority    string   `json:"priority"`
+	Status      string   `json:"status"`
+	Tags        []string `json:"tags"`
+	Urgency     float64  `json:"urgency"`
+}
+
+// Tasks returns the pending tasks from the most urgent.
+func (s *taskwarrior) Tasks() ([]Task, error) {
+	out, err := s.run("status:"+taskwarriorPending, "export")
+	if err != nil {
+		return nil, err
+	}
+
+	var exported []taskwarriorTask
+
+	err = json.Unmarshal(out, &exported)
+	if err != nil {
+		return nil, err
+	}
+
+	exported = slices.DeleteFunc(exported, func(t taskwarriorTask) bool {
+		return t.Status != taskwarriorPending
+	})
+
+	slices.SortStableFunc(exported, func(a, b taskwarriorTask) int {
+		return cmp.Compare(b.Urgency, a.Urgency)
+	})
+
+	tasks := make([]Task, 0, len(exported))
+
+	for _, t := range exported {
+		task := Task{
+			ID:          t.UUID,
+			Description: t.Description,
+			Priority:    t.Priority,
+			Labels:      t.Tags,
+		}
+
+		// the levels of a project such as `Home.Garden` become those of a
+		// hierarchical tag
+		if t.Project != "" {
+			task.Projects = []string{strings.ReplaceAll(t.Project, ".", "/")}
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+// Done marks the task as done.
+func (s *taskwarrior) Done(task Task) error {
+	_, err := s.run(task.ID, "done")
+
+	return err
+}
+
+// Annotate adds the note to the annotations of the task.
+func (s *taskwarrior) Annotate(task Task, note string) error {
+	_, err := s.run(task.ID, "annotate", "--", note)
+
+	return err
+}
+
+// run runs a Taskwarrior command without asking for confirmation and returns
+// its output. The error output of the command is included in the error if it
+// fails.
+func (s *taskwarrior) run(args ...string) ([]byte, error) {
+	args = append([]string{"rc.confirmation=off", "rc.verbose=nothing"}, args...)
+
+	//nolint:gosec // the arguments are not run by a shell
+	out, err := exec.Command(s.cmd, args...).Output()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return nil, fmt.Errorf(
+			"%s: %w: %s",
+			s.cmd,
+			err,
+			bytes.TrimSpace(exitErr.Stderr),
+		)
+	}
+
+	return out, err
+}
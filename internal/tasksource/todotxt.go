@@ -0,0 +1,164 @@
+package tasksource
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	todoDateFormat = "2006-01-02"
+	todoDonePrefix = "x "
+)
+
+var errTaskChanged = errors.New("task was changed or removed")
+
+// todoTxt reads tasks from a file in the todo.txt format
+// (https://github.com/todotxt/todo.txt).
+type todoTxt struct {
+	path string
+}
+
+// Tasks returns the tasks that are not done, from the highest priority. Tasks
+// with the same priority keep their order in the file.
+func (s *todoTxt) Tasks() ([]Task, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, todoDonePrefix) {
+			continue
+		}
+
+		task := parseTodo(line)
+		task.ID = strconv.Itoa(i + 1)
+
+		tasks = append(tasks, task)
+	}
+
+	// tasks without a priority come last
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		switch {
+		case a.Priority == b.Priority:
+			return 0
+		case a.Priority == "":
+			return 1
+		case b.Priority == "":
+			return -1
+		}
+
+		return cmp.Compare(a.Priority, b.Priority)
+	})
+
+	return tasks, nil
+}
+
+// Done marks the task as done by prefixing its line with `x` and the date of
+// completion. The priority of the task is dropped as recommended by the
+// todo.txt format.
+func (s *todoTxt) Done(task Task) error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(b), "\n")
+
+	// the file might have been edited while the task was worked on, so the
+	// task is looked up by its contents if it is no longer on the same line
+	i, _ := strconv.Atoi(task.ID)
+	i--
+
+	if i < 0 || i >= len(lines) || strings.TrimSuffix(lines[i], "\r") != task.line {
+		i = slices.IndexFunc(lines, func(line string) bool {
+			return strings.TrimSuffix(line, "\r") == task.line
+		})
+	}
+
+	if i < 0 {
+		return fmt.Errorf("%w: %s", errTaskChanged, task.Description)
+	}
+
+	lines[i] = completeTodo(lines[i], time.Now())
+
+	return os.WriteFile(s.path, []byte(strings.Join(lines, "\n")), info.Mode())
+}
+
+// Annotate is not supported since todo.txt tasks have no notes.
+func (s *todoTxt) Annotate(Task, string) error {
+	return ErrNotesUnsupported
+}
+
+// parseTodo parses a line such as `(A) 2024-01-02 Call Mom +family @phone`.
+// The description leaves out the priority, the creation date, and the
+// projects and contexts.
+func parseTodo(line string) Task {
+	task := Task{line: line}
+
+	fields := strings.Fields(line)
+
+	if len(fields) > 0 && isPriority(fields[0]) {
+		task.Priority = fields[0][1:2]
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 {
+		if _, err := time.Parse(todoDateFormat, fields[0]); err == nil {
+			fields = fields[1:]
+		}
+	}
+
+	words := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		switch {
+		case len(field) > 1 && field[0] == '+':
+			if !slices.Contains(task.Projects, field[1:]) {
+				task.Projects = append(task.Projects, field[1:])
+			}
+		case len(field) > 1 && field[0] == '@':
+			if !slices.Contains(task.Contexts, field[1:]) {
+				task.Contexts = append(task.Contexts, field[1:])
+			}
+		default:
+			words = append(words, field)
+		}
+	}
+
+	task.Description = strings.Join(words, " ")
+
+	return task
+}
+
+// completeTodo returns the line of a task that was done at the specified
+// time.
+func completeTodo(line string, at time.Time) string {
+	line = strings.TrimLeft(line, " ")
+
+	if len(line) > 3 && isPriority(line[:3]) && line[3] == ' ' {
+		line = strings.TrimLeft(line[3:], " ")
+	}
+
+	return todoDonePrefix + at.Format(todoDateFormat) + " " + line
+}
+
+// isPriority reports whether s is a priority such as `(A)`.
+func isPriority(s string) bool {
+	return len(s) == 3 && s[0] == '(' && s[1] >= 'A' && s[1] <= 'Z' && s[2] == ')'
+}
@@ -0,0 +1,103 @@
+package testutil
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1" //nolint:gosec // git object names are SHA-1 hashes
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// emptyTree is the name of the tree object with no entries.
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// GitCommit is a commit created by GitRepo. Parents are the names of earlier
+// commits.
+type GitCommit struct {
+	Time    time.Time
+	Name    string
+	Author  string
+	Subject string
+	Parents []string
+}
+
+// GitRepo creates a git repository in a temporary directory without running
+// git. The commits are written as loose objects and the main branch, which is
+// checked out, points to the last one. It returns the directory and the hash
+// of each commit by name.
+func GitRepo(t *testing.T, commits []GitCommit) (string, map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	gitDir := filepath.Join(dir, ".git")
+	hashes := make(map[string]string)
+
+	var last string
+
+	for _, c := range commits {
+		var body strings.Builder
+
+		fmt.Fprintf(&body, "tree %s\n", emptyTree)
+
+		for _, parent := range c.Parents {
+			fmt.Fprintf(&body, "parent %s\n", hashes[parent])
+		}
+
+		signature := fmt.Sprintf(
+			"%s <%s@example.com> %d +0000",
+			c.Author,
+			strings.ToLower(c.Author),
+			c.Time.Unix(),
+		)
+
+		fmt.Fprintf(&body, "author %s\ncommitter %s\n\n%s\n", signature, signature, c.Subject)
+
+		object := fmt.Sprintf("commit %d\x00%s", body.Len(), body.String())
+		sum := sha1.Sum([]byte(object)) //nolint:gosec // git object names are SHA-1 hashes
+		hash := hex.EncodeToString(sum[:])
+
+		var compressed bytes.Buffer
+
+		zw := zlib.NewWriter(&compressed)
+
+		if _, err := zw.Write([]byte(object)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		writeGitFile(t, filepath.Join(gitDir, "objects", hash[:2], hash[2:]), compressed.String())
+
+		hashes[c.Name] = hash
+		last = hash
+	}
+
+	writeGitFile(t, filepath.Join(gitDir, "HEAD"), "ref: refs/heads/main\n")
+	writeGitFile(
+		t,
+		filepath.Join(gitDir, "packed-refs"),
+		"# pack-refs with: peeled fully-peeled sorted\n"+last+" refs/heads/main\n",
+	)
+
+	return dir, hashes
+}
+
+// writeGitFile creates the file at path along with its parent directories.
+func writeGitFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,158 @@
+// Package theme provides the color palettes used to style the timer, reports,
+// and other terminal output
+package theme
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Theme is a named color palette. Every color is a hex color code such as
+// `#B0DB43`.
+type Theme struct {
+	Name          string `mapstructure:"-"`
+	Work          string `mapstructure:"work"`
+	ShortBreak    string `mapstructure:"short_break"`
+	LongBreak     string `mapstructure:"long_break"`
+	Accent        string `mapstructure:"accent"`
+	Muted         string `mapstructure:"muted"`
+	Success       string `mapstructure:"success"`
+	Error         string `mapstructure:"error"`
+	Info          string `mapstructure:"info"`
+	Highlight     string `mapstructure:"highlight"`
+	ProgressStart string `mapstructure:"progress_start"`
+	ProgressEnd   string `mapstructure:"progress_end"`
+}
+
+// Names of the built-in themes.
+const (
+	Dark           = "dark"
+	Light          = "light"
+	HighContrast   = "high-contrast"
+	ColorblindSafe = "colorblind"
+)
+
+// builtins are the themes that are available without a theme file.
+var builtins = map[string]Theme{
+	Dark: {
+		Name:          Dark,
+		Work:          "#B0DB43",
+		ShortBreak:    "#12EAEA",
+		LongBreak:     "#C492B1",
+		Accent:        "#DB2763",
+		Muted:         "#585858",
+		Success:       "#78BC61",
+		Error:         "#DA3E52",
+		Info:          "#5FD7FF",
+		Highlight:     "#FFFFFF",
+		ProgressStart: "#5A56E0",
+		ProgressEnd:   "#EE6FF8",
+	},
+	Light: {
+		Name:          Light,
+		Work:          "#2E7D32",
+		ShortBreak:    "#00838F",
+		LongBreak:     "#8E24AA",
+		Accent:        "#C2185B",
+		Muted:         "#757575",
+		Success:       "#2E7D32",
+		Error:         "#C62828",
+		Info:          "#0277BD",
+		Highlight:     "#000000",
+		ProgressStart: "#3949AB",
+		ProgressEnd:   "#AD1457",
+	},
+	HighContrast: {
+		Name:          HighContrast,
+		Work:          "#00FF00",
+		ShortBreak:    "#00FFFF",
+		LongBreak:     "#FF00FF",
+		Accent:        "#FFFF00",
+		Muted:         "#C0C0C0",
+		Success:       "#00FF00",
+		Error:         "#FF0000",
+		Info:          "#00FFFF",
+		Highlight:     "#FFFFFF",
+		ProgressStart: "#FFFFFF",
+		ProgressEnd:   "#FFFF00",
+	},
+	// Uses the Okabe-Ito palette whose colors remain distinguishable with the
+	// common forms of color blindness.
+	ColorblindSafe: {
+		Name:          ColorblindSafe,
+		Work:          "#0072B2",
+		ShortBreak:    "#E69F00",
+		LongBreak:     "#CC79A7",
+		Accent:        "#D55E00",
+		Muted:         "#999999",
+		Success:       "#009E73",
+		Error:         "#D55E00",
+		Info:          "#56B4E9",
+		Highlight:     "#F0E442",
+		ProgressStart: "#56B4E9",
+		ProgressEnd:   "#0072B2",
+	},
+}
+
+// current is the theme used by Current.
+var current = builtins[Dark]
+
+// Builtin returns the built-in theme with the specified name.
+func Builtin(name string) (Theme, bool) {
+	t, ok := builtins[strings.ToLower(name)]
+
+	return t, ok
+}
+
+// Names returns the names of the built-in themes in alphabetical order.
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+
+	for name := range builtins {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// Colors returns the name and value of each color in the theme.
+func (t Theme) Colors() map[string]string {
+	return map[string]string{
+		"work":           t.Work,
+		"short_break":    t.ShortBreak,
+		"long_break":     t.LongBreak,
+		"accent":         t.Accent,
+		"muted":          t.Muted,
+		"success":        t.Success,
+		"error":          t.Error,
+		"info":           t.Info,
+		"highlight":      t.Highlight,
+		"progress_start": t.ProgressStart,
+		"progress_end":   t.ProgressEnd,
+	}
+}
+
+// Set changes the theme returned by Current.
+func Set(t Theme) {
+	current = t
+}
+
+// Current returns the theme that output should be styled with. It is the dark
+// theme unless changed with Set.
+func Current() Theme {
+	return current
+}
+
+// RGB returns the red, green, and blue components of a hex color code. Invalid
+// codes are treated as black.
+func RGB(hex string) (r, g, b uint8) {
+	n, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+
+	return uint8(n >> 16), uint8(n >> 8), uint8(n) //nolint:gosec // 24-bit value
+}
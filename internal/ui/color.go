@@ -2,54 +2,44 @@ package ui
 
 import (
 	"github.com/pterm/pterm"
-)
 
-var DarkTheme bool
+	"github.com/ayoisaiah/focus/internal/theme"
+)
 
-func Green(a any) string {
-	if DarkTheme {
-		return pterm.LightGreen(a)
-	}
+// colorize renders a in the specified hex color. It respects
+// pterm.DisableColor.
+func colorize(hex string, a any) string {
+	r, g, b := theme.RGB(hex)
 
-	return pterm.Green(a)
+	return pterm.NewRGB(r, g, b).Sprint(a)
 }
 
-func Cyan(a any) string {
-	if DarkTheme {
-		return pterm.LightCyan(a)
-	}
-
-	return pterm.Cyan(a)
+// Success renders a in the success color of the current theme.
+func Success(a any) string {
+	return colorize(theme.Current().Success, a)
 }
 
-func Magenta(a any) string {
-	if DarkTheme {
-		return pterm.LightMagenta(a)
-	}
-
-	return pterm.Magenta(a)
+// Error renders a in the error color of the current theme.
+func Error(a any) string {
+	return colorize(theme.Current().Error, a)
 }
 
-func Blue(a any) string {
-	if DarkTheme {
-		return pterm.LightBlue(a)
-	}
-
-	return pterm.Blue(a)
+// Info renders a in the info color of the current theme.
+func Info(a any) string {
+	return colorize(theme.Current().Info, a)
 }
 
-func Red(a any) string {
-	if DarkTheme {
-		return pterm.LightRed(a)
-	}
+// Accent renders a in the accent color of the current theme.
+func Accent(a any) string {
+	return colorize(theme.Current().Accent, a)
+}
 
-	return pterm.Red(a)
+// Muted renders a in the muted color of the current theme.
+func Muted(a any) string {
+	return colorize(theme.Current().Muted, a)
 }
 
+// Highlight renders a in the highlight color of the current theme.
 func Highlight(a any) string {
-	if DarkTheme {
-		return pterm.LightWhite(a)
-	}
-
-	return pterm.Black(a)
+	return colorize(theme.Current().Highlight, a)
 }
@@ -7,6 +7,8 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ayoisaiah/focus/internal/theme"
 )
 
 type (
@@ -19,32 +21,40 @@ type (
 		EndTime           time.Time `json:"end_date"`
 		Name              string    `json:"name"`
 		Tags              []string  `json:"tags"`
+		DailyGoal         string    `json:"daily_goal,omitempty"`
 		WorkCycle         int       `json:"work_cycle"`
 		LongBreakInterval int       `json:"long_break_interval"`
+		Elapsed           int       `json:"elapsed,omitempty"`
+		Flow              bool      `json:"flow,omitempty"`
 	}
 )
 
-var defaultStyle = style{
-	success: lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#78BC61")),
-	error: lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#DA3E52")),
+// currentStyle returns the styles of the current theme.
+func currentStyle() style {
+	palette := theme.Current()
+
+	return style{
+		success: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Success)),
+		error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Error)),
+	}
 }
 
 func SessionAdded() {
-	fmt.Println(defaultStyle.success.Render("session added successfully"))
+	fmt.Println(currentStyle().success.Render("session added successfully"))
 }
 
 func Error(err error) {
-	fmt.Println(defaultStyle.success.Render(err.Error()))
+	fmt.Println(currentStyle().success.Render(err.Error()))
 }
 
 func Fatal(err error) tea.Cmd {
-	fmt.Println(defaultStyle.success.Render(err.Error()))
+	fmt.Println(currentStyle().success.Render(err.Error()))
 	return tea.Quit
 }
 
 func Quit(err error) {
-	fmt.Println(defaultStyle.error.Render(err.Error()))
+	fmt.Println(currentStyle().error.Render(err.Error()))
 	os.Exit(1)
 }
@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/ayoisaiah/focus/internal/gitrepo"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+type (
+	// CommitRecord is the number of commits made during the work sessions
+	// with a tag.
+	CommitRecord struct {
+		Name        string  `json:"name"`
+		Commits     int     `json:"commits"`
+		Pomodoros   int     `json:"pomodoros"`
+		PerPomodoro float64 `json:"per_pomodoro"`
+	}
+
+	// Commits correlates the commits in a git repository with the work
+	// sessions in the reporting period. A commit belongs to the session that
+	// was running when it was authored.
+	Commits struct {
+		Repo    string           `json:"repo"`
+		Tags    []CommitRecord   `json:"tags"`
+		Commits []gitrepo.Commit `json:"commits"`
+		// Total is the number of commits in the reporting period, including
+		// those made outside work sessions.
+		Total int `json:"total"`
+		// InSessions is the number of commits made during work sessions.
+		InSessions  int     `json:"in_sessions"`
+		Pomodoros   int     `json:"pomodoros"`
+		PerPomodoro float64 `json:"per_pomodoro"`
+	}
+
+	// CommitOptions selects the repository and commits that sessions are
+	// correlated with. Author matches the name or email of the commit author
+	// if it is not empty.
+	CommitOptions struct {
+		Repo   gitrepo.Repo
+		Author string
+	}
+)
+
+// sessionAt returns the session that was running at the time of the commit.
+func sessionAt(sessions []*models.Session, c gitrepo.Commit) *models.Session {
+	for _, sess := range sessions {
+		if !c.AuthorTime.Before(sess.StartTime) && !c.AuthorTime.After(sess.EndTime) {
+			return sess
+		}
+	}
+
+	return nil
+}
+
+// matchesAuthor reports whether the commit was authored by the specified
+// author.
+func matchesAuthor(c gitrepo.Commit, author string) bool {
+	if author == "" {
+		return true
+	}
+
+	author = strings.ToLower(author)
+
+	return strings.Contains(strings.ToLower(c.Author), author) ||
+		strings.Contains(strings.ToLower(c.Email), author)
+}
+
+// perPomodoro returns the number of commits per pomodoro.
+func perPomodoro(commits, pomodoros int) float64 {
+	if pomodoros == 0 {
+		return 0
+	}
+
+	return float64(commits) / float64(pomodoros)
+}
+
+// ComputeCommits reads the commits authored in the reporting period and
+// counts those made during each completed work session, by tag. Merge commits
+// are left out since they don't represent new work. It does nothing if no
+// repository is set in CommitOptions, and must be called after Compute. The
+// database is not used, so the connection can be released beforehand.
+func (s *Stats) ComputeCommits() error {
+	if s.CommitOptions == nil {
+		return nil
+	}
+
+	log, err := s.CommitOptions.Repo.Log(s.StartTime)
+	if err != nil {
+		return err
+	}
+
+	result := Commits{
+		Repo:    s.CommitOptions.Repo.Name(),
+		Commits: []gitrepo.Commit{},
+	}
+
+	var completed []*models.Session
+
+	for _, sess := range s.Sessions {
+		if sess.Completed {
+			completed = append(completed, sess)
+		}
+	}
+
+	result.Pomodoros = len(completed)
+
+	counts := make(map[*models.Session]int)
+
+	for _, c := range log {
+		if c.AuthorTime.Before(s.StartTime) || c.AuthorTime.After(s.EndTime) ||
+			len(c.Parents) > 1 || !matchesAuthor(c, s.CommitOptions.Author) {
+			continue
+		}
+
+		result.Total++
+		result.Commits = append(result.Commits, c)
+
+		if sess := sessionAt(completed, c); sess != nil {
+			result.InSessions++
+			counts[sess]++
+		}
+	}
+
+	result.PerPomodoro = perPomodoro(result.InSessions, result.Pomodoros)
+
+	tags := make(map[string]*CommitRecord)
+
+	for _, sess := range completed {
+		sessTags := rollUpTags(sess.Tags)
+		if len(sessTags) == 0 {
+			sessTags = []string{"uncategorized"}
+		}
+
+		for _, tag := range sessTags {
+			if tags[tag] == nil {
+				tags[tag] = &CommitRecord{Name: tag}
+			}
+
+			tags[tag].Pomodoros++
+			tags[tag].Commits += counts[sess]
+		}
+	}
+
+	result.Tags = make([]CommitRecord, 0, len(tags))
+
+	for _, v := range tags {
+		v.PerPomodoro = perPomodoro(v.Commits, v.Pomodoros)
+		result.Tags = append(result.Tags, *v)
+	}
+
+	slices.SortFunc(result.Tags, func(a, b CommitRecord) int {
+		return cmp.Or(
+			cmp.Compare(b.PerPomodoro, a.PerPomodoro),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	s.Commits = &result
+
+	return nil
+}
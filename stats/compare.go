@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+type (
+	// Totals holds the session counts and focus time for a period.
+	Totals struct {
+		Completed int           `json:"completed"`
+		Abandoned int           `json:"abandoned"`
+		Duration  time.Duration `json:"duration"`
+	}
+
+	// TagDelta is the change in focus time for a tag between two periods.
+	TagDelta struct {
+		Name     string        `json:"name"`
+		Previous time.Duration `json:"previous"`
+		Delta    time.Duration `json:"delta"`
+	}
+
+	// Comparison holds the totals of the period preceding the reporting period
+	// and the change from those totals to the reporting period.
+	Comparison struct {
+		StartTime time.Time  `json:"start_time"`
+		EndTime   time.Time  `json:"end_time"`
+		Tags      []TagDelta `json:"tags"`
+		Previous  Totals     `json:"previous"`
+		Delta     Totals     `json:"delta"`
+	}
+)
+
+// totals returns the totals of the summary.
+func (s *Summary) totals() Totals {
+	return Totals{
+		Completed: s.Completed,
+		Abandoned: s.Abandoned,
+		Duration:  s.TotalTime,
+	}
+}
+
+// previousPeriod returns the period of the same length that immediately
+// precedes the reporting period. Periods spanning whole days are shifted by
+// calendar days so that daylight saving time changes are accounted for.
+func (s *Stats) previousPeriod() (start, end time.Time) {
+	span := s.EndTime.Sub(s.StartTime).Round(time.Minute)
+
+	days := timeutil.Round(span.Hours() / timeutil.HoursInADay)
+
+	diff := span - time.Duration(days)*timeutil.HoursInADay*time.Hour
+	if days > 0 && diff.Abs() <= time.Hour {
+		return s.StartTime.AddDate(0, 0, -days), s.StartTime
+	}
+
+	return s.StartTime.Add(-span), s.StartTime
+}
+
+// computeComparison calculates the summary of the previous equivalent period
+// and its difference from the reporting period. The summary of the reporting
+// period must be computed first.
+func (s *Stats) computeComparison() error {
+	start, end := s.previousPeriod()
+
+	sessions, err := s.DB.GetSessions(start, end, s.Tags)
+	if err != nil {
+		return err
+	}
+
+	prev := &Stats{
+		StartTime: start,
+		EndTime:   end,
+		Sessions:  sessions,
+	}
+
+	prev.computeSummary()
+
+	current, previous := s.Summary.totals(), prev.Summary.totals()
+
+	c := &Comparison{
+		StartTime: start,
+		EndTime:   end,
+		Previous:  previous,
+		Delta: Totals{
+			Completed: current.Completed - previous.Completed,
+			Abandoned: current.Abandoned - previous.Abandoned,
+			Duration:  current.Duration - previous.Duration,
+		},
+	}
+
+	for name, d := range s.Summary.Tags {
+		c.Tags = append(c.Tags, TagDelta{
+			Name:     name,
+			Previous: prev.Summary.Tags[name],
+			Delta:    d - prev.Summary.Tags[name],
+		})
+	}
+
+	for name, d := range prev.Summary.Tags {
+		if _, ok := s.Summary.Tags[name]; !ok {
+			c.Tags = append(c.Tags, TagDelta{
+				Name:     name,
+				Previous: d,
+				Delta:    -d,
+			})
+		}
+	}
+
+	slices.SortStableFunc(c.Tags, func(a, b TagDelta) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	s.Comparison = c
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package stats
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+// DaySummary is the focus time and number of completed work sessions on a
+// single day along with the time spent on each tag and in each hour.
+type DaySummary struct {
+	Sessions  []*models.Session
+	Tags      []Record
+	Hourly    [24]time.Duration
+	Total     time.Duration
+	Completed int
+}
+
+// SummariseDay summarises the sessions on the day that contains t. Sessions
+// are listed in the order they started, and tags in the order of the tag
+// hierarchy, with each parent tag right before its children.
+func SummariseDay(sessions []*models.Session, t time.Time) DaySummary {
+	s := &Stats{
+		StartTime: timeutil.RoundToStart(t),
+		EndTime:   timeutil.RoundToEnd(t),
+		Sessions:  sessions,
+	}
+
+	s.computeSummary()
+	s.computeAggregates()
+
+	summary := DaySummary{
+		Sessions:  slices.Clone(sessions),
+		Tags:      tagTree(s.Summary.Tags, ""),
+		Total:     s.Summary.TotalTime,
+		Completed: s.Summary.Completed,
+	}
+
+	for i := range summary.Hourly {
+		summary.Hourly[i] = s.Aggregates.Hourly[fmt.Sprintf("%02d:00", i)]
+	}
+
+	slices.SortFunc(summary.Sessions, func(a, b *models.Session) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	return summary
+}
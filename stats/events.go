@@ -0,0 +1,181 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/report"
+)
+
+type (
+	// LiveStatus is the status of the running timer as sent to the dashboard.
+	LiveStatus struct {
+		*report.Status
+		Running   bool `json:"running"`
+		Remaining int  `json:"remaining"`
+	}
+
+	// watcher detects changes to a file by polling its modification time.
+	watcher struct {
+		modTime time.Time
+		path    string
+		exists  bool
+	}
+)
+
+// pollInterval is how often the status and database files are checked for
+// changes.
+const pollInterval = time.Second
+
+var errStreamingUnsupported = errors.New("streaming is not supported")
+
+// changed reports whether the watched file was modified, created or removed
+// since the last call.
+func (fw *watcher) changed() bool {
+	info, err := os.Stat(fw.path)
+	if err != nil {
+		if !fw.exists {
+			return false
+		}
+
+		fw.exists = false
+		fw.modTime = time.Time{}
+
+		return true
+	}
+
+	if fw.exists && info.ModTime().Equal(fw.modTime) {
+		return false
+	}
+
+	fw.exists = true
+	fw.modTime = info.ModTime()
+
+	return true
+}
+
+// readStatus returns the status of the running timer from the status file.
+func readStatus() LiveStatus {
+	var ls LiveStatus
+
+	// the status file is left behind if the timer did not exit cleanly
+	if !config.TimerRunning() {
+		return ls
+	}
+
+	b, err := os.ReadFile(config.StatusFilePath())
+	if err != nil {
+		return ls
+	}
+
+	var s report.Status
+
+	err = json.Unmarshal(b, &s)
+	if err != nil {
+		return ls
+	}
+
+	// a flowtime session has no end time, so it runs until the timer stops
+	if s.Flow {
+		ls.Status = &s
+		ls.Running = true
+
+		return ls
+	}
+
+	remaining := int(time.Until(s.EndTime).Seconds())
+	if remaining < 0 {
+		return ls
+	}
+
+	ls.Status = &s
+	ls.Running = true
+	ls.Remaining = remaining
+
+	return ls
+}
+
+// sendEvent writes a single Server-Sent Event to the client.
+func sendEvent(w http.ResponseWriter, event string, data []byte) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	if err != nil {
+		return err
+	}
+
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return nil
+}
+
+// Events streams live updates to the dashboard using Server-Sent Events. A
+// status event is sent whenever the running timer updates the status file, and
+// the stats for the requested period are recomputed and sent as a stats event
+// whenever a session is persisted to the database.
+func (s *Stats) Events(w http.ResponseWriter, r *http.Request) error {
+	if _, ok := w.(http.Flusher); !ok {
+		http.Error(w, errStreamingUnsupported.Error(), http.StatusInternalServerError)
+
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	st, err := s.forRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return nil
+	}
+
+	statusFile := &watcher{path: config.StatusFilePath()}
+	dbFile := &watcher{path: config.DBFilePath()}
+
+	// the page already contains the current stats
+	dbFile.changed()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if statusFile.changed() {
+			b, err := json.Marshal(readStatus())
+			if err != nil {
+				return err
+			}
+
+			if sendEvent(w, "status", b) != nil {
+				// the client has gone away
+				return nil
+			}
+		}
+
+		if dbFile.changed() {
+			b, err := st.computeStats()
+			if err != nil {
+				// the database may be briefly locked by the timer, so try
+				// again on the next tick
+				slog.Info("unable to compute live stats", slog.Any("error", err))
+
+				dbFile.modTime = time.Time{}
+			} else if sendEvent(w, "stats", b) != nil {
+				return nil
+			}
+		}
+
+		select {
+		case <-r.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package stats
+
+import (
+	"bytes"
+	"cmp"
+	"embed"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+type (
+	// ReportFormat is the output format of a static stats report.
+	ReportFormat string
+
+	// reportData is the data used to render a static stats report.
+	reportData struct {
+		StartTime     time.Time
+		EndTime       time.Time
+		CSS           template.CSS
+		Logo          template.URL
+		Chart         template.HTML
+		ChartURL      string
+		Tags          []Record
+		Daily         []Record
+		Sessions      []*models.Session
+		Summary       Summary
+		Goals         Goals
+		Interruptions Interruptions
+	}
+)
+
+const (
+	FormatHTML     ReportFormat = "html"
+	FormatMarkdown ReportFormat = "md"
+)
+
+const (
+	chartWidth     = 800
+	chartHeight    = 240
+	chartPadding   = 30
+	chartBarGap    = 4
+	chartLabelY    = chartHeight + chartPadding/2 + 4
+	chartMaxLabels = 15
+	chartColor     = "#B0DB43"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+var reportFuncs = map[string]any{
+	"duration": FormatDuration,
+	"datetime": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format("Jan 02, 2006 03:04 PM")
+	},
+	"date": func(t time.Time) string {
+		return t.Format("Jan 02, 2006")
+	},
+	"tags": func(tags []string) string {
+		return strings.Join(tags, " · ")
+	},
+	"status": func(sess *models.Session) string {
+		if sess.Completed {
+			return "completed"
+		}
+
+		return "abandoned"
+	},
+	"elapsed":       sessionTime,
+	"interruptions": countInterruptions,
+	"inc": func(i int) int {
+		return i + 1
+	},
+	// cell escapes pipes and line breaks in Markdown table cells
+	"cell": strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace,
+}
+
+var errInvalidReportFormat = fmt.Errorf(
+	"report format must be one of: %s, %s",
+	FormatHTML,
+	FormatMarkdown,
+)
+
+// FormatDuration expresses a duration in hours and minutes (e.g. 3h 25m).
+func FormatDuration(d time.Duration) string {
+	hrs, mins := timeutil.MinsToHoursAndMins(int(d.Minutes()))
+
+	if hrs == 0 {
+		return fmt.Sprintf("%dm", mins)
+	}
+
+	if mins == 0 {
+		return fmt.Sprintf("%dh", hrs)
+	}
+
+	return fmt.Sprintf("%dh %dm", hrs, mins)
+}
+
+// Compute retrieves the sessions in the reporting period from the database and
+// calculates the summary and aggregates. The database connection must be open.
+// Commits are correlated separately with ComputeCommits once the connection
+// has been released.
+func (s *Stats) Compute() error {
+	sessions, err := s.DB.GetSessions(s.StartTime, s.EndTime, s.Tags)
+	if err != nil {
+		return err
+	}
+
+	s.Sessions = sessions
+
+	// For all-time, set start time to the date of the first session
+	if s.StartTime.IsZero() && len(s.Sessions) > 0 {
+		s.StartTime = timeutil.RoundToStart(s.Sessions[0].StartTime)
+	}
+
+	s.computeSummary()
+	s.computeAggregates()
+	s.computeInterruptions()
+
+	if s.Compare && !s.StartTime.IsZero() {
+		err = s.computeComparison()
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.computeGoals()
+}
+
+// dailyChart renders the daily totals as an SVG bar chart.
+func dailyChart(daily []Record) string {
+	var b strings.Builder
+
+	fmt.Fprintf(
+		&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="100%%" role="img" aria-label="Daily totals">`,
+		chartWidth,
+		chartHeight+chartPadding,
+	)
+
+	var highest time.Duration
+
+	for _, r := range daily {
+		highest = max(highest, r.Duration)
+	}
+
+	if len(daily) == 0 || highest == 0 {
+		b.WriteString("</svg>")
+
+		return b.String()
+	}
+
+	barWidth := float64(chartWidth)/float64(len(daily)) - chartBarGap
+
+	// skip labels on long periods so that they don't overlap
+	labelEvery := max(1, len(daily)/chartMaxLabels)
+
+	for i, r := range daily {
+		x := float64(i) * (barWidth + chartBarGap)
+		h := float64(r.Duration) / float64(highest) * chartHeight
+		y := chartHeight - h
+
+		fmt.Fprintf(
+			&b,
+			`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="2" fill="%s"><title>%s: %s</title></rect>`,
+			x, y, barWidth, h, chartColor, r.Name, FormatDuration(r.Duration),
+		)
+
+		if i%labelEvery != 0 {
+			continue
+		}
+
+		label := r.Name
+
+		if d, err := time.Parse(dayFormat, r.Name); err == nil {
+			label = d.Format("Jan 2")
+		}
+
+		fmt.Fprintf(
+			&b,
+			`<text x="%.1f" y="%d" font-size="11" text-anchor="middle" fill="#666">%s</text>`,
+			x+barWidth/2, chartLabelY, label,
+		)
+	}
+
+	b.WriteString("</svg>")
+
+	return b.String()
+}
+
+// tagTree returns the descendants of the parent tag in depth-first order so
+// that each tag is listed right before its children. Siblings are ordered by
+// focus time.
+func tagTree(tags map[string]time.Duration, parent string) []Record {
+	var children []Record
+
+	for k, v := range tags {
+		if tagquery.Parent(k) == parent {
+			children = append(children, Record{Name: k, Parent: parent, Duration: v})
+		}
+	}
+
+	slices.SortStableFunc(children, func(a, b Record) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.Name, b.Name))
+	})
+
+	result := make([]Record, 0, len(children))
+
+	for _, r := range children {
+		result = append(result, r)
+		result = append(result, tagTree(tags, r.Name)...)
+	}
+
+	return result
+}
+
+// reportData prepares the data used to render a static report.
+func (s *Stats) reportData() (*reportData, error) {
+	logo, err := web.ReadFile("web/images/logo.png")
+	if err != nil {
+		return nil, err
+	}
+
+	css, err := inlineCSS()
+	if err != nil {
+		return nil, err
+	}
+
+	data := &reportData{
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		CSS:       template.CSS(css), //nolint:gosec // embedded file
+		Logo: template.URL( //nolint:gosec // embedded file
+			"data:image/png;base64," + base64.StdEncoding.EncodeToString(logo),
+		),
+		Sessions:      s.Sessions,
+		Summary:       s.Summary,
+		Goals:         s.Goals,
+		Interruptions: s.Interruptions,
+	}
+
+	data.Tags = tagTree(s.Summary.Tags, "")
+
+	for k, v := range s.Aggregates.Daily {
+		data.Daily = append(data.Daily, Record{Name: k, Duration: v})
+	}
+
+	sortByName(data.Daily)
+
+	chart := dailyChart(data.Daily)
+
+	data.Chart = template.HTML(chart) //nolint:gosec // generated from numbers and dates
+	data.ChartURL = "data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString(
+		[]byte(chart),
+	)
+
+	return data, nil
+}
+
+// inlineCSS returns the dashboard styles with the fonts embedded as data URLs
+// so that the report does not depend on the stats server.
+func inlineCSS() (string, error) {
+	css, err := web.ReadFile("web/css/styles.css")
+	if err != nil {
+		return "", err
+	}
+
+	s := string(css)
+
+	for _, font := range []string{"Inter-Bold.woff", "Inter-Regular.woff"} {
+		b, err := web.ReadFile("web/fonts/" + font)
+		if err != nil {
+			return "", err
+		}
+
+		s = strings.ReplaceAll(
+			s,
+			"../fonts/"+font,
+			"data:font/woff;base64,"+base64.StdEncoding.EncodeToString(b),
+		)
+	}
+
+	return s, nil
+}
+
+// WriteReport renders a self-contained report of the computed stats in the
+// specified format.
+func (s *Stats) WriteReport(w io.Writer, format ReportFormat) error {
+	data, err := s.reportData()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatHTML:
+		tpl, err := template.New("report.html").
+			Funcs(reportFuncs).
+			ParseFS(templates, "templates/report.html")
+		if err != nil {
+			return err
+		}
+
+		err = tpl.Execute(&buf, data)
+		if err != nil {
+			return err
+		}
+	case FormatMarkdown:
+		tpl, err := texttemplate.New("report.md").
+			Funcs(reportFuncs).
+			ParseFS(templates, "templates/report.md")
+		if err != nil {
+			return err
+		}
+
+		err = tpl.Execute(&buf, data)
+		if err != nil {
+			return err
+		}
+	default:
+		return errInvalidReportFormat
+	}
+
+	_, err = w.Write(buf.Bytes())
+
+	return err
+}
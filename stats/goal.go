@@ -0,0 +1,196 @@
+package stats
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+	"github.com/ayoisaiah/focus/internal/timeutil"
+)
+
+type (
+	// GoalProgress reports how far along a daily or weekly target is.
+	GoalProgress struct {
+		Unit     config.GoalUnit `json:"unit"`
+		Target   int             `json:"target"`
+		Progress int             `json:"progress"`
+	}
+
+	// Goals holds the progress towards the configured goals and the streaks
+	// of consecutive days on which the daily goal was met.
+	Goals struct {
+		Daily         *GoalProgress `json:"daily,omitempty"`
+		Weekly        *GoalProgress `json:"weekly,omitempty"`
+		CurrentStreak int           `json:"current_streak"`
+		LongestStreak int           `json:"longest_streak"`
+	}
+)
+
+const dayFormat = "2006-01-02"
+
+// String returns the goal progress in a compact form (e.g. 3/8).
+func (g *GoalProgress) String() string {
+	if g == nil {
+		return ""
+	}
+
+	s := strconv.Itoa(g.Progress) + "/" + strconv.Itoa(g.Target)
+
+	if g.Unit == config.GoalMinutes {
+		s += " mins"
+	}
+
+	return s
+}
+
+// Met reports whether the target has been reached.
+func (g *GoalProgress) Met() bool {
+	return g != nil && g.Progress >= g.Target
+}
+
+// StartOfWeek returns the start of the ISO week (Monday) that contains t.
+func StartOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+
+	return timeutil.RoundToStart(t.AddDate(0, 0, -offset))
+}
+
+// countsTowardsGoals reports whether a session contributes to the goals.
+func countsTowardsGoals(sess *models.Session, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+
+	for _, t := range sess.Tags {
+		for _, goalTag := range tags {
+			if tagquery.Within(t, goalTag) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// dailyTotals groups the contribution of each session to the goals by day.
+// Completed pomodoros are attributed to the day they ended, while focused
+// minutes are attributed to the day each part of the session timeline started.
+func dailyTotals(
+	sessions []*models.Session,
+	cfg config.GoalsConfig,
+) map[string]int {
+	totals := make(map[string]int)
+	minutes := make(map[string]time.Duration)
+
+	for _, sess := range sessions {
+		if !countsTowardsGoals(sess, cfg.Tags) {
+			continue
+		}
+
+		if cfg.Unit == config.GoalPomodoros {
+			if sess.Completed {
+				totals[sess.EndTime.Format(dayFormat)]++
+			}
+
+			continue
+		}
+
+		for _, event := range sess.Timeline {
+			minutes[event.StartTime.Format(dayFormat)] += event.EndTime.Sub(
+				event.StartTime,
+			)
+		}
+	}
+
+	for k, v := range minutes {
+		totals[k] = int(v.Minutes())
+	}
+
+	return totals
+}
+
+// streaks returns the current and longest runs of consecutive days on which
+// the target was met. An unmet target today does not break the current streak
+// since the day is not over yet.
+func streaks(
+	totals map[string]int,
+	target int,
+	now time.Time,
+) (current, longest int) {
+	if target <= 0 || len(totals) == 0 {
+		return 0, 0
+	}
+
+	first := now
+
+	for k := range totals {
+		d, err := time.ParseInLocation(dayFormat, k, now.Location())
+		if err == nil && d.Before(first) {
+			first = d
+		}
+	}
+
+	today := now.Format(dayFormat)
+
+	var run, runBeforeToday int
+
+	for d := timeutil.RoundToStart(first); !d.After(now); d = d.AddDate(0, 0, 1) {
+		key := d.Format(dayFormat)
+
+		if key == today {
+			runBeforeToday = run
+		}
+
+		if totals[key] >= target {
+			run++
+		} else {
+			run = 0
+		}
+
+		longest = max(longest, run)
+	}
+
+	if totals[today] >= target {
+		return run, longest
+	}
+
+	return runBeforeToday, longest
+}
+
+// ComputeGoals calculates the progress towards the configured goals as of now.
+// The sessions should span the whole period of interest: at least the current
+// week for the weekly goal, and all time for accurate streaks.
+func ComputeGoals(
+	sessions []*models.Session,
+	cfg config.GoalsConfig,
+	now time.Time,
+) Goals {
+	var g Goals
+
+	totals := dailyTotals(sessions, cfg)
+
+	if cfg.Daily > 0 {
+		g.Daily = &GoalProgress{
+			Unit:     cfg.Unit,
+			Target:   cfg.Daily,
+			Progress: totals[now.Format(dayFormat)],
+		}
+
+		g.CurrentStreak, g.LongestStreak = streaks(totals, cfg.Daily, now)
+	}
+
+	if cfg.Weekly > 0 {
+		g.Weekly = &GoalProgress{
+			Unit:   cfg.Unit,
+			Target: cfg.Weekly,
+		}
+
+		for d := StartOfWeek(now); !d.After(now); d = d.AddDate(0, 0, 1) {
+			g.Weekly.Progress += totals[d.Format(dayFormat)]
+		}
+	}
+
+	return g
+}
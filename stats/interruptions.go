@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+type (
+	// InterruptionCount is the number of internal and external interruptions.
+	InterruptionCount struct {
+		Internal int `json:"internal"`
+		External int `json:"external"`
+	}
+
+	// InterruptionRecord is the number of interruptions for a tag or hour.
+	InterruptionRecord struct {
+		Name string `json:"name"`
+		InterruptionCount
+	}
+
+	// Interruptions summarises the interruptions logged in the reporting
+	// period.
+	Interruptions struct {
+		Tags       []InterruptionRecord `json:"tags"`
+		Hourly     []InterruptionRecord `json:"hourly"`
+		Total      InterruptionCount    `json:"total"`
+		PerSession float64              `json:"per_session"`
+	}
+)
+
+// add increments the count for the kind of interruption.
+func (c *InterruptionCount) add(kind models.InterruptionKind) {
+	switch kind {
+	case models.InternalInterruption:
+		c.Internal++
+	case models.ExternalInterruption:
+		c.External++
+	}
+}
+
+// Sum returns the total number of interruptions.
+func (c InterruptionCount) Sum() int {
+	return c.Internal + c.External
+}
+
+// countInterruptions returns the number of interruptions in a session.
+func countInterruptions(sess *models.Session) InterruptionCount {
+	var c InterruptionCount
+
+	for _, in := range sess.Interruptions {
+		c.add(in.Kind)
+	}
+
+	return c
+}
+
+// computeInterruptions counts the interruptions logged within the reporting
+// period by tag and hour of day, and the average per work session.
+func (s *Stats) computeInterruptions() {
+	var result Interruptions
+
+	tags := make(map[string]*InterruptionCount)
+	hourly := make(map[string]*InterruptionCount)
+
+	for i := range 24 {
+		hourly[fmt.Sprintf("%02d:00", i)] = &InterruptionCount{}
+	}
+
+	for _, sess := range s.Sessions {
+		sessTags := rollUpTags(sess.Tags)
+		if len(sessTags) == 0 {
+			sessTags = []string{"uncategorized"}
+		}
+
+		for _, in := range sess.Interruptions {
+			if in.Time.Before(s.StartTime) || in.Time.After(s.EndTime) {
+				continue
+			}
+
+			result.Total.add(in.Kind)
+			hourly[in.Time.Format("15:00")].add(in.Kind)
+
+			for _, tag := range sessTags {
+				if tags[tag] == nil {
+					tags[tag] = &InterruptionCount{}
+				}
+
+				tags[tag].add(in.Kind)
+			}
+		}
+	}
+
+	if len(s.Sessions) > 0 {
+		result.PerSession = float64(result.Total.Sum()) / float64(len(s.Sessions))
+	}
+
+	for k, v := range tags {
+		result.Tags = append(result.Tags, InterruptionRecord{k, *v})
+	}
+
+	for k, v := range hourly {
+		result.Hourly = append(result.Hourly, InterruptionRecord{k, *v})
+	}
+
+	slices.SortFunc(result.Tags, func(a, b InterruptionRecord) int {
+		return cmp.Or(cmp.Compare(b.Sum(), a.Sum()), cmp.Compare(a.Name, b.Name))
+	})
+
+	slices.SortFunc(result.Hourly, func(a, b InterruptionRecord) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	s.Interruptions = result
+}
@@ -0,0 +1,175 @@
+package stats
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/store"
+)
+
+// openMetricsContentType is the content type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// sessionStates maps each session type to its state in the focus_session
+// stateset metric.
+var sessionStates = []struct {
+	name  config.SessionType
+	state string
+}{
+	{config.Work, "work"},
+	{config.ShortBreak, "short_break"},
+	{config.LongBreak, "long_break"},
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writeMetricHeader writes the TYPE and HELP lines of a metric family.
+func writeMetricHeader(buf *bytes.Buffer, name, metricType, help string) {
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, metricType)
+	fmt.Fprintf(buf, "# HELP %s %s\n", name, help)
+}
+
+// sessionTime returns the total focus time recorded in a session's timeline.
+func sessionTime(sess *models.Session) time.Duration {
+	var d time.Duration
+
+	for _, event := range sess.Timeline {
+		d += event.EndTime.Sub(event.StartTime)
+	}
+
+	return d
+}
+
+// writeSessionMetrics writes the all-time focus time per tag and the number of
+// completed and abandoned sessions. They are gauges rather than counters since
+// they go down when sessions are deleted or their tags are changed.
+func writeSessionMetrics(buf *bytes.Buffer, sessions []*models.Session) {
+	tags := make(map[string]time.Duration)
+
+	var completed, abandoned int
+
+	for _, sess := range sessions {
+		d := sessionTime(sess)
+
+		for _, tag := range sess.Tags {
+			tags[tag] += d
+		}
+
+		if len(sess.Tags) == 0 {
+			tags["uncategorized"] += d
+		}
+
+		if sess.Completed {
+			completed++
+		} else {
+			abandoned++
+		}
+	}
+
+	names := make([]string, 0, len(tags))
+	for k := range tags {
+		names = append(names, k)
+	}
+
+	slices.Sort(names)
+
+	writeMetricHeader(buf, "focus_time_seconds", "gauge", "Time spent in work sessions by tag.")
+
+	for _, name := range names {
+		fmt.Fprintf(
+			buf,
+			"focus_time_seconds{tag=\"%s\"} %g\n",
+			labelEscaper.Replace(name),
+			tags[name].Seconds(),
+		)
+	}
+
+	writeMetricHeader(buf, "focus_sessions", "gauge", "Number of work sessions by status.")
+	fmt.Fprintf(buf, "focus_sessions{status=\"completed\"} %d\n", completed)
+	fmt.Fprintf(buf, "focus_sessions{status=\"abandoned\"} %d\n", abandoned)
+}
+
+// writeStatusMetrics writes the state of the running timer.
+func writeStatusMetrics(buf *bytes.Buffer, status LiveStatus) {
+	writeMetricHeader(buf, "focus_session", "stateset", "Type of the current session.")
+
+	for _, v := range sessionStates {
+		var val int
+		if status.Running && config.SessionType(status.Name) == v.name {
+			val = 1
+		}
+
+		fmt.Fprintf(buf, "focus_session{focus_session=\"%s\"} %d\n", v.state, val)
+	}
+
+	var workCycle, interval int
+	if status.Running {
+		workCycle = status.WorkCycle
+		interval = status.LongBreakInterval
+	}
+
+	writeMetricHeader(buf, "focus_running", "gauge", "Whether a timer is counting down.")
+	fmt.Fprintf(buf, "focus_running %d\n", boolToInt(status.Running))
+
+	writeMetricHeader(buf, "focus_remaining_seconds", "gauge", "Time left in the current session.")
+	fmt.Fprintf(buf, "focus_remaining_seconds %d\n", status.Remaining)
+
+	writeMetricHeader(buf, "focus_work_cycle", "gauge", "Position of the current work session in the cycle.")
+	fmt.Fprintf(buf, "focus_work_cycle %d\n", workCycle)
+
+	writeMetricHeader(buf, "focus_long_break_interval", "gauge", "Number of work sessions before a long break.")
+	fmt.Fprintf(buf, "focus_long_break_interval %d\n", interval)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// Metrics exposes the all-time session totals and the state of the running
+// timer in the OpenMetrics text format so that they can be scraped by
+// Prometheus.
+func (s *Stats) Metrics(w http.ResponseWriter, _ *http.Request) error {
+	var sessions []*models.Session
+
+	dbMu.Lock()
+
+	err := store.Use(s.DB, func() error {
+		var err error
+
+		sessions, err = s.DB.GetSessions(time.Time{}, time.Now(), nil)
+
+		return err
+	})
+
+	dbMu.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	writeSessionMetrics(&buf, sessions)
+	writeStatusMetrics(&buf, readStatus())
+
+	buf.WriteString("# EOF\n")
+
+	w.Header().Set("Content-Type", openMetricsContentType)
+
+	// a failed write means the scraper has gone away
+	_, _ = w.Write(buf.Bytes())
+
+	return nil
+}
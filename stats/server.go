@@ -7,15 +7,18 @@ import (
 	"fmt"
 	"html/template"
 	"log"
+	"log/slog"
 	"math"
 	"net/http"
 	"os/exec"
 	"runtime"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/pterm/pterm"
 
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/tagquery"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 	"github.com/ayoisaiah/focus/store"
 )
@@ -35,41 +38,73 @@ type errorHandler func(w http.ResponseWriter, r *http.Request) error
 func (h errorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	err := h(w, r)
 	if err != nil {
-		pterm.Fatal.Println(err)
+		slog.Error(
+			"unable to handle request",
+			slog.String("path", r.URL.Path),
+			slog.Any("error", err),
+		)
+
+		http.Error(
+			w,
+			http.StatusText(http.StatusInternalServerError),
+			http.StatusInternalServerError,
+		)
 	}
 }
 
 //go:embed web/*
 var web embed.FS
 
-var db store.DB
+// dbMu prevents concurrent requests from opening the database connection
+// at the same time.
+var dbMu sync.Mutex
 
 var tpl = template.Must(
 	template.New("index.html").ParseFS(web, "web/index.html"),
 )
 
-// computeSummary calculates the total minutes, completed sessions,
+// computeStats calculates the total minutes, completed sessions,
 // and abandoned sessions for the current time period.
 func (s *Stats) computeStats() ([]byte, error) {
-	sessions, err := db.GetSessions(s.StartTime, s.EndTime, s.Tags)
+	dbMu.Lock()
+
+	err := store.Use(s.DB, s.Compute)
+
+	dbMu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// walking the git history can take a while, so it is done after the
+	// database is released
+	err = s.ComputeCommits()
 	if err != nil {
 		return nil, err
 	}
 
-	s.Sessions = sessions
+	return s.ToJSON()
+}
+
+// computeGoals calculates the progress towards the configured goals. All
+// sessions are considered so that streaks are not cut off by the reporting
+// period.
+func (s *Stats) computeGoals() error {
+	now := time.Now()
 
-	// For all-time, set start time to the date of the first session
-	if s.StartTime.IsZero() && len(s.Sessions) > 0 {
-		s.StartTime = timeutil.RoundToStart(s.Sessions[0].StartTime)
+	sessions, err := s.DB.GetSessions(time.Time{}, now, nil)
+	if err != nil {
+		return err
 	}
 
-	s.computeSummary()
-	// s.computeAggregates()
+	s.Goals = ComputeGoals(sessions, s.GoalsConfig, now)
 
-	return s.ToJSON()
+	return nil
 }
 
-func (s *Stats) Index(w http.ResponseWriter, r *http.Request) error {
+// forRequest returns a copy of the stats for the reporting period and tag
+// query specified in the query string of the request.
+func (s *Stats) forRequest(r *http.Request) (*Stats, error) {
 	query := r.URL.Query()
 
 	start := query.Get("start_time")
@@ -90,29 +125,48 @@ func (s *Stats) Index(w http.ResponseWriter, r *http.Request) error {
 
 	endTime = timeutil.RoundToEnd(endTime)
 
-	var tagList []string
-	if tags != "" {
-		tagList = strings.Split(tags, ",")
+	tagQuery, err := tagquery.Parse(tags)
+	if err != nil {
+		return nil, err
 	}
 
-	s.StartTime = startTime
-	s.EndTime = endTime
-	s.Tags = tagList
+	return &Stats{
+		DB:            s.DB,
+		GoalsConfig:   s.GoalsConfig,
+		CommitOptions: s.CommitOptions,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		Tags:          tagQuery,
+		Compare:       true,
+	}, nil
+}
 
-	b, err := s.computeStats()
+func (s *Stats) Index(w http.ResponseWriter, r *http.Request) error {
+	st, err := s.forRequest(r)
 	if err != nil {
-		return err
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return nil
+	}
+
+	b, err := st.computeStats()
+	if store.IsLocked(err) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+		return nil
 	}
 
-	fmt.Println(string(b))
+	if err != nil {
+		return err
+	}
 
 	var buf bytes.Buffer
 
 	err = tpl.Execute(&buf, &TemplateData{
-		StartTime: startTime.Format(time.RFC3339Nano),
-		EndTime:   endTime.Format(time.RFC3339Nano),
+		StartTime: st.StartTime.Format(time.RFC3339Nano),
+		EndTime:   st.EndTime.Format(time.RFC3339Nano),
 		Days: int(
-			math.Round(endTime.Sub(startTime).Seconds() / (24 * 60 * 60)),
+			math.Round(st.EndTime.Sub(st.StartTime).Seconds() / (24 * 60 * 60)),
 		),
 		Stats: string(b),
 	})
@@ -148,17 +202,33 @@ func openbrowser(url string) {
 	}
 }
 
-func Server(dB store.DB, port uint) error {
+func Server(
+	db store.DB,
+	cfg *config.Config,
+	port uint,
+	commits *CommitOptions,
+) error {
 	mux := http.NewServeMux()
 
+	// the connection is opened for each request so that the timer can
+	// access the database while the server is running
+	err := db.Close()
+	if err != nil {
+		return err
+	}
+
 	s := &Stats{
-		DB: db,
+		DB:            db,
+		GoalsConfig:   cfg.Goals,
+		CommitOptions: commits,
 	}
 
 	staticFS := http.FS(web)
 	fs := http.FileServer(staticFS)
 
 	mux.Handle("/web/", fs)
+	mux.Handle("/events", errorHandler(s.Events))
+	mux.Handle("/metrics", errorHandler(s.Metrics))
 	mux.Handle("/", errorHandler(s.Index))
 
 	pterm.Info.Printfln("starting server on port: %d", port)
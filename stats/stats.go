@@ -12,7 +12,9 @@ import (
 
 	"github.com/maruel/natural"
 
+	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 	"github.com/ayoisaiah/focus/store"
 )
@@ -20,48 +22,55 @@ import (
 type (
 	// Stats represents the computed focus statistics for a period of time.
 	Stats struct {
-		Aggregates      Aggregates        `json:"aggregates"`
-		StartTime       time.Time         `json:"start_time"`
-		EndTime         time.Time         `json:"end_time"`
-		DB              store.DB          `json:"-"`
-		Sessions        []*models.Session `json:"-"`
-		LastDayTimeline []Timeline        `json:"timeline"`
-		Summary         Summary           `json:"summary"`
-		Tags            []string          `json:"tags"`
+		Aggregates      Aggregates         `json:"aggregates"`
+		StartTime       time.Time          `json:"start_time"`
+		EndTime         time.Time          `json:"end_time"`
+		DB              store.DB           `json:"-"`
+		Sessions        []*models.Session  `json:"-"`
+		LastDayTimeline []Timeline         `json:"timeline"`
+		Summary         Summary            `json:"summary"`
+		Tags            *tagquery.Query    `json:"-"`
+		GoalsConfig     config.GoalsConfig `json:"-"`
+		Goals           Goals              `json:"goals"`
+		Comparison      *Comparison        `json:"comparison,omitempty"`
+		Interruptions   Interruptions      `json:"interruptions"`
+		// CommitOptions enables the correlation of sessions with the commits
+		// in a git repository if it is not nil.
+		CommitOptions *CommitOptions `json:"-"`
+		Commits       *Commits       `json:"commits,omitempty"`
+		Compare       bool           `json:"-"`
 	}
 
 	Timeline struct {
 		StartTime time.Time     `json:"start_time"`
 		Tags      []string      `json:"tags"`
+		Notes     string        `json:"notes,omitempty"`
 		Duration  time.Duration `json:"duration"`
 	}
 
 	Record struct {
 		Name     string        `json:"name"`
+		Parent   string        `json:"parent,omitempty"`
 		Duration time.Duration `json:"duration"`
 	}
 
 	statsJSON struct {
-		StartTime       time.Time  `json:"start_time"`
-		EndTime         time.Time  `json:"end_time"`
-		Tags            []Record   `json:"tags"`
-		Hourly          []Record   `json:"hourly"`
-		LastDayTimeline []Timeline `json:"timeline"`
-		Daily           []Record   `json:"daily"`
-		Weekday         []Record   `json:"weekday"`
-		Weekly          []Record   `json:"weekly"`
-		Yearly          []Record   `json:"yearly"`
-		Monthly         []Record   `json:"monthly"`
-		Totals          struct {
-			Completed int           `json:"completed"`
-			Abandoned int           `json:"abandoned"`
-			Duration  time.Duration `json:"duration"`
-		} `json:"totals"`
-		Averages struct {
-			Completed int           `json:"completed"`
-			Abandoned int           `json:"abandoned"`
-			Duration  time.Duration `json:"duration"`
-		} `json:"averages"`
+		StartTime       time.Time     `json:"start_time"`
+		EndTime         time.Time     `json:"end_time"`
+		Tags            []Record      `json:"tags"`
+		Hourly          []Record      `json:"hourly"`
+		LastDayTimeline []Timeline    `json:"timeline"`
+		Daily           []Record      `json:"daily"`
+		Weekday         []Record      `json:"weekday"`
+		Weekly          []Record      `json:"weekly"`
+		Yearly          []Record      `json:"yearly"`
+		Monthly         []Record      `json:"monthly"`
+		Goals           Goals         `json:"goals"`
+		Comparison      *Comparison   `json:"comparison,omitempty"`
+		Interruptions   Interruptions `json:"interruptions"`
+		Commits         *Commits      `json:"commits,omitempty"`
+		Totals          Totals        `json:"totals"`
+		Averages        Totals        `json:"averages"`
 	}
 
 	aggregatePeriod string
@@ -263,6 +272,7 @@ func (s *Stats) computeAggregates() {
 
 					t.StartTime = date
 					t.Tags = sess.Tags
+					t.Notes = sess.Notes
 					t.Duration = end.Sub(date)
 					s.LastDayTimeline = append(s.LastDayTimeline, t)
 
@@ -326,8 +336,26 @@ func (s *Stats) computeAggregates() {
 	s.Aggregates = totals
 }
 
+// rollUpTags returns the tags of a session along with each of their ancestors
+// so that the time spent on a tag is also credited to its parents. Each tag
+// appears once even if the session has several tags with a common ancestor.
+func rollUpTags(tags []string) []string {
+	var result []string
+
+	for _, tag := range tags {
+		for _, t := range tagquery.Lineage(tag) {
+			if !slices.Contains(result, t) {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
 // computeSummary calculates the total minutes, completed sessions, and
-// abandoned sessions for the current time period.
+// abandoned sessions for the current time period. Time spent on hierarchical
+// tags is rolled up into their parents.
 func (s *Stats) computeSummary() {
 	var totals Summary
 
@@ -340,7 +368,7 @@ func (s *Stats) computeSummary() {
 
 		totals.TotalTime += duration
 
-		for _, tag := range sess.Tags {
+		for _, tag := range rollUpTags(sess.Tags) {
 			totals.Tags[tag] += duration
 		}
 
@@ -427,18 +455,23 @@ func (s *Stats) ToJSON() ([]byte, error) {
 	r.StartTime = s.StartTime
 	r.EndTime = s.EndTime
 
-	r.Totals.Completed = s.Summary.Completed
-	r.Totals.Abandoned = s.Summary.Abandoned
-	r.Totals.Duration = s.Summary.TotalTime
-	r.Averages.Completed = s.Summary.AvgCompleted
-	r.Averages.Abandoned = s.Summary.AvgAbandoned
-	r.Averages.Duration = s.Summary.AvgTime
+	r.Totals = s.Summary.totals()
+	r.Averages = Totals{
+		Completed: s.Summary.AvgCompleted,
+		Abandoned: s.Summary.AvgAbandoned,
+		Duration:  s.Summary.AvgTime,
+	}
 
 	r.LastDayTimeline = s.LastDayTimeline
+	r.Goals = s.Goals
+	r.Comparison = s.Comparison
+	r.Interruptions = s.Interruptions
+	r.Commits = s.Commits
 
 	for k, v := range s.Summary.Tags {
 		r.Tags = append(r.Tags, Record{
 			Name:     k,
+			Parent:   tagquery.Parent(k),
 			Duration: v,
 		})
 	}
@@ -486,7 +519,7 @@ func (s *Stats) ToJSON() ([]byte, error) {
 	}
 
 	slices.SortStableFunc(r.Tags, func(a, b Record) int {
-		return cmp.Compare(b.Duration, a.Duration)
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), cmp.Compare(a.Name, b.Name))
 	})
 
 	sortByName(r.Hourly)
@@ -0,0 +1,119 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/gitrepo"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/testutil"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestCommits(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 11, 23, 59, 59, 0, time.Local)
+	at := func(hour, minute int) time.Time {
+		return start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	dir, _ := testutil.GitRepo(t, []testutil.GitCommit{
+		{Name: "yesterday", Author: "Jane", Subject: "Initial commit", Time: at(-2, 0)},
+		{
+			Name:    "handler",
+			Author:  "Jane",
+			Subject: "Add the sessions handler",
+			Time:    at(9, 10),
+			Parents: []string{"yesterday"},
+		},
+		{
+			Name:    "review",
+			Author:  "John",
+			Subject: "Address review comments",
+			Time:    at(9, 15),
+			Parents: []string{"handler"},
+		},
+		{
+			Name:    "tests",
+			Author:  "Jane",
+			Subject: "Test the sessions handler",
+			Time:    at(9, 20),
+			Parents: []string{"review"},
+		},
+		{
+			Name:    "lunch",
+			Author:  "Jane",
+			Subject: "Fix typo",
+			Time:    at(12, 0),
+			Parents: []string{"tests"},
+		},
+		{
+			Name:    "merge",
+			Author:  "Jane",
+			Subject: "Merge branch 'docs'",
+			Time:    at(14, 5),
+			Parents: []string{"lunch", "yesterday"},
+		},
+	})
+
+	repo, err := gitrepo.Find(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	abandoned := pomodoro(at(14, 0), "acme/api")
+	abandoned.Completed = false
+
+	s := &stats.Stats{
+		DB: &memDB{
+			sessions: []*models.Session{
+				pomodoro(at(9, 0), "acme/api"),
+				pomodoro(at(10, 0), "writing"),
+				abandoned,
+			},
+		},
+		StartTime: start,
+		EndTime:   end,
+		CommitOptions: &stats.CommitOptions{
+			Repo:   repo,
+			Author: "jane@",
+		},
+	}
+
+	err = s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.ComputeCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.Commits
+
+	assert.Equal(t, 3, got.Total)
+	assert.Equal(t, 2, got.InSessions)
+	assert.Equal(t, 2, got.Pomodoros)
+	assert.InDelta(t, 1.0, got.PerPomodoro, 0.001)
+
+	assert.Equal(t, []stats.CommitRecord{
+		{Name: "acme", Commits: 2, Pomodoros: 1, PerPomodoro: 2},
+		{Name: "acme/api", Commits: 2, Pomodoros: 1, PerPomodoro: 2},
+		{Name: "writing", Commits: 0, Pomodoros: 1, PerPomodoro: 0},
+	}, got.Tags)
+
+	subjects := make([]string, 0, len(got.Commits))
+
+	for _, c := range got.Commits {
+		subjects = append(subjects, c.Subject)
+	}
+
+	assert.Equal(t, []string{
+		"Fix typo",
+		"Test the sessions handler",
+		"Add the sessions handler",
+	}, subjects)
+}
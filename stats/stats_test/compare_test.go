@@ -0,0 +1,65 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestComparison(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 17, 23, 59, 59, 0, time.Local)
+
+	abandoned := pomodoro(start.AddDate(0, 0, -3).Add(9*time.Hour), "review")
+	abandoned.Completed = false
+
+	db := &memDB{
+		sessions: []*models.Session{
+			// previous week
+			pomodoro(start.AddDate(0, 0, -5).Add(9*time.Hour), "review"),
+			pomodoro(start.AddDate(0, 0, -5).Add(10*time.Hour), "billing"),
+			abandoned,
+			// current week
+			pomodoro(start.Add(9*time.Hour), "review"),
+			pomodoro(start.Add(10*time.Hour), "review"),
+			pomodoro(start.AddDate(0, 0, 2).Add(9*time.Hour), "review"),
+		},
+	}
+
+	s := &stats.Stats{
+		DB:        db,
+		StartTime: start,
+		EndTime:   end,
+		Compare:   true,
+	}
+
+	err := s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &stats.Comparison{
+		StartTime: start.AddDate(0, 0, -7),
+		EndTime:   start,
+		Previous: stats.Totals{
+			Completed: 2,
+			Abandoned: 1,
+			Duration:  75 * time.Minute,
+		},
+		Delta: stats.Totals{
+			Completed: 1,
+			Abandoned: -1,
+			Duration:  0,
+		},
+		Tags: []stats.TagDelta{
+			{Name: "billing", Previous: 25 * time.Minute, Delta: -25 * time.Minute},
+			{Name: "review", Previous: 50 * time.Minute, Delta: 25 * time.Minute},
+		},
+	}
+
+	assert.Equal(t, want, s.Comparison)
+}
@@ -0,0 +1,54 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestSummariseDay(t *testing.T) {
+	start := time.Date(2024, 3, 11, 9, 0, 0, 0, time.Local)
+
+	abandoned := pomodoro(start.Add(3*time.Hour), "writing")
+	abandoned.Completed = false
+	abandoned.Timeline[0].EndTime = abandoned.StartTime.Add(10 * time.Minute)
+
+	sessions := []*models.Session{
+		pomodoro(start.Add(time.Hour), "acme/web"),
+		pomodoro(start, "acme/api"),
+		pomodoro(start.Add(2 * time.Hour)),
+		abandoned,
+	}
+
+	got := stats.SummariseDay(sessions, start)
+
+	assert.Equal(t, 3, got.Completed)
+	assert.Equal(t, 85*time.Minute, got.Total)
+	assert.Equal(t, []stats.Record{
+		{Name: "acme", Duration: 50 * time.Minute},
+		{Name: "acme/api", Parent: "acme", Duration: 25 * time.Minute},
+		{Name: "acme/web", Parent: "acme", Duration: 25 * time.Minute},
+		{Name: "uncategorized", Duration: 25 * time.Minute},
+		{Name: "writing", Duration: 10 * time.Minute},
+	}, got.Tags)
+
+	assert.Equal(t, []*models.Session{
+		sessions[1],
+		sessions[0],
+		sessions[2],
+		sessions[3],
+	}, got.Sessions)
+
+	var hourly [24]time.Duration
+
+	hourly[9] = 25 * time.Minute
+	hourly[10] = 25 * time.Minute
+	hourly[11] = 25 * time.Minute
+	hourly[12] = 10 * time.Minute
+
+	assert.Equal(t, hourly, got.Hourly)
+}
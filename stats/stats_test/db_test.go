@@ -0,0 +1,61 @@
+package stats_test
+
+import (
+	"time"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+)
+
+// memDB is an in-memory implementation of store.DB.
+type memDB struct {
+	sessions []*models.Session
+}
+
+func (db *memDB) GetSessions(
+	since, until time.Time,
+	query *tagquery.Query,
+) ([]*models.Session, error) {
+	var result []*models.Session
+
+	for _, sess := range db.sessions {
+		if sess.EndTime.After(since) && !sess.StartTime.After(until) &&
+			query.Match(sess.Tags) {
+			result = append(result, sess)
+		}
+	}
+
+	return result, nil
+}
+
+func (db *memDB) UpdateSessions(map[time.Time]*models.Session) error {
+	return nil
+}
+
+func (db *memDB) DeleteSessions([]time.Time) error {
+	return nil
+}
+
+func (db *memDB) AddTask(*models.Task) error {
+	return nil
+}
+
+func (db *memDB) GetTask(int) (*models.Task, error) {
+	return nil, nil //nolint:nilnil // tasks are not used by the stats
+}
+
+func (db *memDB) GetTasks() ([]*models.Task, error) {
+	return nil, nil
+}
+
+func (db *memDB) UpdateTask(*models.Task) error {
+	return nil
+}
+
+func (db *memDB) Open() error {
+	return nil
+}
+
+func (db *memDB) Close() error {
+	return nil
+}
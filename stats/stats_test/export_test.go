@@ -0,0 +1,49 @@
+package stats_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestReportNotes(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 11, 23, 59, 59, 0, time.Local)
+
+	sess := pomodoro(start.Add(9*time.Hour), "billing")
+	sess.Notes = "Fixed invoice totals\nReviewed a|b split"
+
+	s := &stats.Stats{
+		DB:        &memDB{sessions: []*models.Session{sess}},
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	err := s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	err = s.WriteReport(&buf, stats.FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, buf.String(), `| Fixed invoice totals<br>Reviewed a\|b split |`)
+
+	buf.Reset()
+
+	err = s.WriteReport(&buf, stats.FormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, buf.String(), "Fixed invoice totals\nReviewed a|b split")
+}
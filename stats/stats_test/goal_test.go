@@ -0,0 +1,135 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+// pomodoro returns a completed 25 minute session that started at the
+// specified time.
+func pomodoro(start time.Time, tags ...string) *models.Session {
+	end := start.Add(25 * time.Minute)
+
+	return &models.Session{
+		StartTime: start,
+		EndTime:   end,
+		Name:      config.Work,
+		Tags:      tags,
+		Duration:  25 * time.Minute,
+		Completed: true,
+		Timeline: []models.SessionTimeline{
+			{StartTime: start, EndTime: end},
+		},
+	}
+}
+
+func TestComputeGoals(t *testing.T) {
+	// Thursday
+	now := time.Date(2024, 3, 14, 18, 0, 0, 0, time.Local)
+	day := func(offset, hour int) time.Time {
+		d := now.AddDate(0, 0, offset)
+
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, 0, 0, 0, time.Local)
+	}
+
+	sessions := []*models.Session{
+		// Two weeks ago: a one day streak
+		pomodoro(day(-14, 9)),
+		pomodoro(day(-14, 10)),
+		// Friday to Tuesday: a four day streak broken on Wednesday
+		pomodoro(day(-6, 9)),
+		pomodoro(day(-6, 10), "billing"),
+		pomodoro(day(-5, 9)),
+		pomodoro(day(-5, 10)),
+		pomodoro(day(-4, 9)),
+		pomodoro(day(-4, 10)),
+		pomodoro(day(-3, 9), "billing"),
+		pomodoro(day(-3, 10)),
+		pomodoro(day(-3, 11)),
+		pomodoro(day(-2, 9)),
+		pomodoro(day(-1, 9), "billing"),
+		// Today
+		pomodoro(day(0, 9), "billing"),
+	}
+
+	testCases := []struct {
+		Name string
+		Cfg  config.GoalsConfig
+		Want stats.Goals
+	}{
+		{
+			Name: "no goals configured",
+			Cfg:  config.GoalsConfig{Unit: config.GoalPomodoros},
+			Want: stats.Goals{},
+		},
+		{
+			Name: "daily and weekly pomodoro goals",
+			Cfg: config.GoalsConfig{
+				Unit:   config.GoalPomodoros,
+				Daily:  2,
+				Weekly: 10,
+			},
+			Want: stats.Goals{
+				Daily: &stats.GoalProgress{
+					Unit:     config.GoalPomodoros,
+					Target:   2,
+					Progress: 1,
+				},
+				Weekly: &stats.GoalProgress{
+					Unit:     config.GoalPomodoros,
+					Target:   10,
+					Progress: 6,
+				},
+				CurrentStreak: 0,
+				LongestStreak: 4,
+			},
+		},
+		{
+			Name: "unmet goal today does not break the streak",
+			Cfg: config.GoalsConfig{
+				Unit:  config.GoalMinutes,
+				Daily: 25,
+			},
+			Want: stats.Goals{
+				Daily: &stats.GoalProgress{
+					Unit:     config.GoalMinutes,
+					Target:   25,
+					Progress: 25,
+				},
+				CurrentStreak: 7,
+				LongestStreak: 7,
+			},
+		},
+		{
+			Name: "goals restricted to a tag",
+			Cfg: config.GoalsConfig{
+				Unit:  config.GoalPomodoros,
+				Tags:  []string{"billing"},
+				Daily: 1,
+			},
+			Want: stats.Goals{
+				Daily: &stats.GoalProgress{
+					Unit:     config.GoalPomodoros,
+					Target:   1,
+					Progress: 1,
+				},
+				CurrentStreak: 2,
+				LongestStreak: 2,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			got := stats.ComputeGoals(sessions, tc.Cfg, now)
+
+			assert.Equal(t, tc.Want, got)
+		})
+	}
+}
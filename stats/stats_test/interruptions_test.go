@@ -0,0 +1,63 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestInterruptions(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 11, 23, 59, 59, 0, time.Local)
+
+	first := pomodoro(start.Add(9*time.Hour), "acme/api")
+	first.Interruptions = []models.Interruption{
+		{Time: start.Add(9*time.Hour + 5*time.Minute), Kind: models.InternalInterruption},
+		{
+			Time:   start.Add(9*time.Hour + 10*time.Minute),
+			Kind:   models.ExternalInterruption,
+			Reason: "phone call",
+		},
+	}
+
+	second := pomodoro(start.Add(10*time.Hour), "writing")
+	second.Interruptions = []models.Interruption{
+		{Time: start.Add(10*time.Hour + 5*time.Minute), Kind: models.InternalInterruption},
+	}
+
+	s := &stats.Stats{
+		DB: &memDB{
+			sessions: []*models.Session{
+				first,
+				second,
+				pomodoro(start.Add(11 * time.Hour)),
+			},
+		},
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	err := s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := s.Interruptions
+
+	assert.Equal(t, stats.InterruptionCount{Internal: 2, External: 1}, got.Total)
+	assert.Equal(t, 1.0, got.PerSession)
+
+	assert.Equal(t, []stats.InterruptionRecord{
+		{Name: "acme", InterruptionCount: stats.InterruptionCount{Internal: 1, External: 1}},
+		{Name: "acme/api", InterruptionCount: stats.InterruptionCount{Internal: 1, External: 1}},
+		{Name: "writing", InterruptionCount: stats.InterruptionCount{Internal: 1}},
+	}, got.Tags)
+
+	assert.Len(t, got.Hourly, 24)
+	assert.Equal(t, stats.InterruptionCount{Internal: 1, External: 1}, got.Hourly[9].InterruptionCount)
+	assert.Equal(t, stats.InterruptionCount{Internal: 1}, got.Hourly[10].InterruptionCount)
+}
@@ -0,0 +1,80 @@
+package stats_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestMetrics(t *testing.T) {
+	// no timer is running in an empty data directory
+	config.SetDataDir(t.TempDir())
+
+	start := time.Date(2024, 3, 14, 9, 0, 0, 0, time.Local)
+
+	abandoned := pomodoro(start.Add(2*time.Hour), "writing")
+	abandoned.Completed = false
+	abandoned.Timeline[0].EndTime = abandoned.StartTime.Add(10 * time.Minute)
+
+	s := &stats.Stats{
+		DB: &memDB{
+			sessions: []*models.Session{
+				pomodoro(start, "acme/api", `say "hi"`),
+				pomodoro(start.Add(time.Hour)),
+				abandoned,
+			},
+		},
+	}
+
+	rec := httptest.NewRecorder()
+
+	err := s.Metrics(rec, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# TYPE focus_time_seconds gauge
+# HELP focus_time_seconds Time spent in work sessions by tag.
+focus_time_seconds{tag="acme/api"} 1500
+focus_time_seconds{tag="say \"hi\""} 1500
+focus_time_seconds{tag="uncategorized"} 1500
+focus_time_seconds{tag="writing"} 600
+# TYPE focus_sessions gauge
+# HELP focus_sessions Number of work sessions by status.
+focus_sessions{status="completed"} 2
+focus_sessions{status="abandoned"} 1
+# TYPE focus_session stateset
+# HELP focus_session Type of the current session.
+focus_session{focus_session="work"} 0
+focus_session{focus_session="short_break"} 0
+focus_session{focus_session="long_break"} 0
+# TYPE focus_running gauge
+# HELP focus_running Whether a timer is counting down.
+focus_running 0
+# TYPE focus_remaining_seconds gauge
+# HELP focus_remaining_seconds Time left in the current session.
+focus_remaining_seconds 0
+# TYPE focus_work_cycle gauge
+# HELP focus_work_cycle Position of the current work session in the cycle.
+focus_work_cycle 0
+# TYPE focus_long_break_interval gauge
+# HELP focus_long_break_interval Number of work sessions before a long break.
+focus_long_break_interval 0
+# EOF
+`
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(
+		t,
+		"application/openmetrics-text; version=1.0.0; charset=utf-8",
+		rec.Header().Get("Content-Type"),
+	)
+	assert.Equal(t, want, rec.Body.String())
+}
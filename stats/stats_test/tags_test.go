@@ -0,0 +1,65 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestHierarchicalTags(t *testing.T) {
+	start := time.Date(2024, 3, 11, 0, 0, 0, 0, time.Local)
+	end := time.Date(2024, 3, 11, 23, 59, 59, 0, time.Local)
+
+	db := &memDB{
+		sessions: []*models.Session{
+			pomodoro(start.Add(9*time.Hour), "acme/api/auth"),
+			pomodoro(start.Add(10*time.Hour), "acme/web"),
+			pomodoro(start.Add(11*time.Hour), "acme/api/auth", "acme/api/db"),
+			pomodoro(start.Add(12*time.Hour), "acmecorp"),
+		},
+	}
+
+	s := &stats.Stats{
+		DB:        db,
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	err := s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]time.Duration{
+		"acme":          75 * time.Minute,
+		"acme/api":      50 * time.Minute,
+		"acme/api/auth": 50 * time.Minute,
+		"acme/api/db":   25 * time.Minute,
+		"acme/web":      25 * time.Minute,
+		"acmecorp":      25 * time.Minute,
+	}, s.Summary.Tags)
+
+	query, err := tagquery.Parse("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s = &stats.Stats{
+		DB:        db,
+		StartTime: start,
+		EndTime:   end,
+		Tags:      query,
+	}
+
+	err = s.Compute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 3, s.Summary.Completed)
+}
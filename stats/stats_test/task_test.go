@@ -0,0 +1,33 @@
+package stats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+func TestTaskPomodoros(t *testing.T) {
+	start := time.Date(2024, 3, 11, 9, 0, 0, 0, time.Local)
+
+	attach := func(sess *models.Session, id int) *models.Session {
+		sess.TaskID = id
+		return sess
+	}
+
+	abandoned := attach(pomodoro(start.Add(3*time.Hour)), 1)
+	abandoned.Completed = false
+
+	sessions := []*models.Session{
+		attach(pomodoro(start), 1),
+		attach(pomodoro(start.Add(time.Hour)), 1),
+		attach(pomodoro(start.Add(2*time.Hour)), 2),
+		abandoned,
+		pomodoro(start.Add(4 * time.Hour)),
+	}
+
+	assert.Equal(t, map[int]int{1: 2, 2: 1}, stats.TaskPomodoros(sessions))
+}
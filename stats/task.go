@@ -0,0 +1,22 @@
+package stats
+
+import (
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+)
+
+// TaskPomodoros returns the number of completed work sessions attached to each
+// task.
+func TaskPomodoros(sessions []*models.Session) map[int]int {
+	m := make(map[int]int)
+
+	for _, sess := range sessions {
+		if sess.TaskID == 0 || !sess.Completed || sess.Name != config.Work {
+			continue
+		}
+
+		m[sess.TaskID]++
+	}
+
+	return m
+}
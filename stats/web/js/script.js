@@ -99,6 +99,108 @@ function plotSummary(data) {
   document.querySelector('#js-abandoned').textContent = data.totals.abandoned;
 }
 
+const charts = {};
+
+function renderChart(name, chart) {
+  if (charts[name]) {
+    charts[name].destroy();
+  }
+
+  charts[name] = chart;
+  chart.render();
+}
+
+function formatGoal(goal) {
+  const unit = goal.unit === 'minutes' ? ' mins' : '';
+
+  return `${goal.progress}/${goal.target}${unit}`;
+}
+
+function plotGoals(data) {
+  const { goals } = data;
+
+  if (!goals.daily && !goals.weekly) {
+    return;
+  }
+
+  const parts = [];
+
+  if (goals.daily) {
+    parts.push(`${formatGoal(goals.daily)} today`);
+  }
+
+  if (goals.weekly) {
+    parts.push(`${formatGoal(goals.weekly)} this week`);
+  }
+
+  document.querySelector('#js-goal').hidden = false;
+  document.querySelector('#js-goal-text').textContent = parts.join(' · ');
+
+  if (goals.daily) {
+    document.querySelector(
+      '#js-goal-streak'
+    ).textContent = `(${goals.current_streak} day streak, best ${goals.longest_streak})`;
+  }
+}
+
+function plotDelta(selector, delta, format, higherIsBetter = true) {
+  const el = document.querySelector(selector);
+  const sign = delta > 0 ? '+' : delta < 0 ? '−' : '±';
+  const better = higherIsBetter ? delta > 0 : delta < 0;
+
+  el.textContent = `${sign}${format(Math.abs(delta))} vs previous period`;
+  el.classList.toggle('up', delta !== 0 && better);
+  el.classList.toggle('down', delta !== 0 && !better);
+}
+
+function plotComparison(data) {
+  const { comparison } = data;
+
+  if (!comparison) {
+    return;
+  }
+
+  const toMinutes = (d) => Math.floor(d / 60000000000);
+
+  plotDelta(
+    '#js-total-time-delta',
+    toMinutes(comparison.delta.duration),
+    toHoursAndMinutes
+  );
+  plotDelta('#js-completed-delta', comparison.delta.completed, String);
+  plotDelta('#js-abandoned-delta', comparison.delta.abandoned, String, false);
+
+  if (!comparison.tags || comparison.tags.length === 0) {
+    return;
+  }
+
+  const categories = [];
+  const current = [];
+  const previous = [];
+
+  comparison.tags.forEach((item) => {
+    categories.push(item.name);
+    current.push(toMinutes(item.previous + item.delta));
+    previous.push(toMinutes(item.previous));
+  });
+
+  const options = getChartOptions(current, categories, 'Tags compared');
+  options.series = [
+    { name: 'This period', data: current },
+    { name: 'Previous period', data: previous },
+  ];
+
+  document.querySelector('#js-comparison').hidden = false;
+
+  renderChart(
+    'comparison',
+    new ApexCharts(
+      document.querySelector('#js-tags-comparison-chart'),
+      options
+    )
+  );
+}
+
 function getChartOptions(seriesData, xaxisCategories, title) {
   const seriesName = 'Focus time';
   const tooltip = {
@@ -165,7 +267,7 @@ function plotWeekday(data) {
     document.querySelector('#js-weekday-chart'),
     weekdayOptions
   );
-  weekdayChart.render();
+  renderChart('weekday', weekdayChart);
 }
 
 function plotMain(data) {
@@ -212,7 +314,7 @@ function plotMain(data) {
     document.getElementById('js-main-chart'),
     mainOptions
   );
-  mainChart.render();
+  renderChart('main', mainChart);
 }
 
 function plotHourly(data) {
@@ -234,16 +336,48 @@ function plotHourly(data) {
     document.querySelector('#js-hourly-chart'),
     hourlyOptions
   );
-  hourlyChart.render();
+  renderChart('hourly', hourlyChart);
 }
 
+// tagParent is the tag whose children are shown in the tags chart. It is
+// empty when the top-level tags are shown.
+let tagParent = '';
+
 function plotTags(data) {
+  const parents = new Set(data.tags.map((item) => item.parent || ''));
+
+  if (!parents.has(tagParent)) {
+    tagParent = '';
+  }
+
   const tagsData = [];
   const tagsCategories = [];
-  data.tags.forEach((item) => {
-    tagsCategories.push(item.name);
-    tagsData.push(Math.floor(item.duration / 60000000000));
-  });
+  let childrenDuration = 0;
+  data.tags
+    .filter((item) => (item.parent || '') === tagParent)
+    .forEach((item) => {
+      tagsCategories.push(item.name);
+      tagsData.push(Math.floor(item.duration / 60000000000));
+      childrenDuration += item.duration;
+    });
+
+  // time logged on the parent tag itself has no child to be shown under, so
+  // it gets its own slice to keep the total equal to the parent's
+  const parent = data.tags.find((item) => item.name === tagParent);
+  if (parent && parent.duration > childrenDuration) {
+    tagsCategories.push(`(untagged below ${tagParent})`);
+    tagsData.push(
+      Math.floor((parent.duration - childrenDuration) / 60000000000)
+    );
+  }
+
+  const upButton = document.querySelector('#js-tags-up');
+  upButton.hidden = tagParent === '';
+  upButton.onclick = () => {
+    const i = tagParent.search(/[/:][^/:]*$/);
+    tagParent = i === -1 ? '' : tagParent.slice(0, i);
+    plotTags(data);
+  };
 
   const tooltip = {
     y: {
@@ -258,11 +392,22 @@ function plotTags(data) {
     chart: {
       height: 300,
       type: 'pie',
+      events: {
+        // drill down into the children of the selected tag
+        dataPointSelection: (event, chartContext, config) => {
+          const name = tagsCategories[config.dataPointIndex];
+
+          if (parents.has(name)) {
+            tagParent = name;
+            plotTags(data);
+          }
+        },
+      },
     },
     labels: tagsCategories,
     tooltip,
     title: {
-      text: 'Tags',
+      text: tagParent === '' ? 'Tags' : `Tags › ${tagParent}`,
       margin: 20,
       style: {
         fontSize: '24px',
@@ -274,7 +419,128 @@ function plotTags(data) {
     document.querySelector('#js-tags-chart'),
     tagOptions
   );
-  tagChart.render();
+  renderChart('tags', tagChart);
+}
+
+function formatRemaining(seconds) {
+  const minutes = String(Math.floor(seconds / 60)).padStart(2, '0');
+  const secs = String(seconds % 60).padStart(2, '0');
+
+  return `${minutes}:${secs}`;
+}
+
+function interruptionSeries(records) {
+  return [
+    {
+      name: 'Internal',
+      data: records.map((item) => item.internal),
+    },
+    {
+      name: 'External',
+      data: records.map((item) => item.external),
+    },
+  ];
+}
+
+function plotInterruptions(data) {
+  const { interruptions } = data;
+  const total = interruptions.total.internal + interruptions.total.external;
+
+  document.querySelector('#js-interruptions').textContent = total;
+  document.querySelector(
+    '#js-interruptions-avg'
+  ).textContent = `(${interruptions.per_session.toFixed(1)} per session)`;
+
+  document.querySelector('#js-interruptions-charts').hidden = total === 0;
+
+  if (total === 0) {
+    return;
+  }
+
+  const interruptionCharts = [
+    ['hourly', 'Interruptions by hour', '#js-interruptions-hourly-chart'],
+    ['tags', 'Interruptions by tag', '#js-interruptions-tags-chart'],
+  ];
+
+  interruptionCharts.forEach(([key, title, selector]) => {
+    const records = interruptions[key] || [];
+    const options = getChartOptions(
+      [],
+      records.map((item) => item.name),
+      title
+    );
+    options.series = interruptionSeries(records);
+    options.chart.stacked = true;
+    options.tooltip = {};
+    options.yaxis.title.text = 'interruptions';
+
+    renderChart(
+      `interruptions-${key}`,
+      new ApexCharts(document.querySelector(selector), options)
+    );
+  });
+}
+
+function plotAll(data) {
+  plotSummary(data);
+  plotGoals(data);
+  plotComparison(data);
+  plotMain(data);
+  plotWeekday(data);
+  plotHourly(data);
+  plotTags(data);
+  plotInterruptions(data);
+}
+
+let statusInterval;
+
+function plotStatus(status) {
+  const el = document.querySelector('#js-status');
+
+  clearInterval(statusInterval);
+
+  if (!status.running) {
+    el.hidden = true;
+    return;
+  }
+
+  const endTime = new Date(status.end_date).getTime();
+  const receivedAt = Date.now();
+
+  const update = () => {
+    let text;
+
+    if (status.flow) {
+      const elapsed =
+        status.elapsed + Math.round((Date.now() - receivedAt) / 1000);
+      text = `${status.name} · ${formatRemaining(elapsed)} elapsed`;
+    } else {
+      const remaining = Math.max(0, Math.round((endTime - Date.now()) / 1000));
+      text = `${status.name} · ${formatRemaining(remaining)} remaining`;
+    }
+
+    if (status.daily_goal) {
+      text += ` · ${status.daily_goal} today`;
+    }
+
+    el.textContent = text;
+  };
+
+  el.hidden = false;
+  update();
+  statusInterval = setInterval(update, 1000);
+}
+
+function subscribe() {
+  const events = new EventSource(`/events${window.location.search}`);
+
+  events.addEventListener('status', (e) => {
+    plotStatus(JSON.parse(e.data));
+  });
+
+  events.addEventListener('stats', (e) => {
+    plotAll(JSON.parse(e.data));
+  });
 }
 
 document.addEventListener('DOMContentLoaded', async () => {
@@ -300,9 +566,10 @@ document.addEventListener('DOMContentLoaded', async () => {
       setup(picker) {
         picker.on('select', (e) => {
           const { start, end } = e.detail;
-          window.location.href = `${
-            window.location.pathname
-          }?start_time=${formatDate(start)}&end_time=${formatDate(end)}`;
+          const params = new URLSearchParams(window.location.search);
+          params.set('start_time', formatDate(start));
+          params.set('end_time', formatDate(end));
+          window.location.href = `${window.location.pathname}?${params}`;
         });
       },
     });
@@ -310,11 +577,8 @@ document.addEventListener('DOMContentLoaded', async () => {
     const body = document.getElementById('body');
     const data = JSON.parse(body.dataset.stats);
 
-    plotSummary(data);
-    plotMain(data);
-    plotWeekday(data);
-    plotHourly(data);
-    plotTags(data);
+    plotAll(data);
+    subscribe();
   } catch (err) {
     console.log(err);
   }
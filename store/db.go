@@ -4,24 +4,53 @@ import (
 	"time"
 
 	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
 )
 
 // DB is the database storage interface.
 type DB interface {
 	// GetSessions returns saved sessions according to the specified time and tag
-	// constraints
+	// constraints. A nil query matches sessions regardless of their tags.
 	GetSessions(
 		since, until time.Time,
-		tags []string,
+		query *tagquery.Query,
 	) ([]*models.Session, error)
-	// UpdateSessions updates one or more Focus sessions.
-	// Each session is created if it doesn't
+	// UpdateSessions updates one or more Focus sessions in a single
+	// transaction. Each session is created if it doesn't
 	// exist already, or overwritten if it does.
 	UpdateSessions(map[time.Time]*models.Session) error
 	// DeleteSessions deletes one or more saved sessions
 	DeleteSessions(startTimes []time.Time) error
+	// AddTask saves a new task and assigns it an ID
+	AddTask(task *models.Task) error
+	// GetTask retrieves the task with the specified ID
+	GetTask(id int) (*models.Task, error)
+	// GetTasks retrieves all saved tasks in the order they were added
+	GetTasks() ([]*models.Task, error)
+	// UpdateTask overwrites an existing task
+	UpdateTask(task *models.Task) error
 	// Close ends the database connection
 	Close() error
 	// Open initiates a database connection
 	Open() error
 }
+
+// Use opens the database connection for the duration of fn and closes it
+// afterwards. Holding the connection only when needed allows other Focus
+// processes (such as the stats server and a running timer) to share the
+// database.
+func Use(db DB, fn func() error) (err error) {
+	err = db.Open()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		cerr := db.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	return fn()
+}
@@ -3,10 +3,11 @@ package store
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/fs"
-	"slices"
 	"time"
 
 	bolt "go.etcd.io/bbolt"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/ayoisaiah/focus/internal/config"
 	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
 	"github.com/ayoisaiah/focus/internal/timeutil"
 )
 
@@ -25,27 +27,39 @@ type Client struct {
 const (
 	sessionBucket = "sessions"
 	focusBucket   = "focus"
+	taskBucket    = "tasks"
 )
 
 var errFocusRunning = errors.New(
 	"is Focus already running? Only one instance can be active at a time",
 )
 
+var errTaskNotFound = errors.New("task not found")
+
+// IsLocked reports whether err was returned because another Focus process
+// held the database for longer than the connection timeout.
+func IsLocked(err error) bool {
+	return errors.Is(err, errFocusRunning) || errors.Is(err, bolterr.ErrTimeout)
+}
+
 func (c *Client) UpdateSessions(sessions map[time.Time]*models.Session) error {
-	for k, v := range sessions {
-		key := timeutil.ToKey(k)
+	return c.Update(func(tx *bolt.Tx) error {
+		for k, v := range sessions {
+			key := timeutil.ToKey(k)
 
-		b, err := json.Marshal(v)
-		if err != nil {
-			return err
-		}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
 
-		return c.Update(func(tx *bolt.Tx) error {
-			return tx.Bucket([]byte(sessionBucket)).Put(key, b)
-		})
-	}
+			err = tx.Bucket([]byte(sessionBucket)).Put(key, b)
+			if err != nil {
+				return err
+			}
+		}
 
-	return nil
+		return nil
+	})
 }
 
 func (c *Client) DeleteSessions(startTimes []time.Time) error {
@@ -63,6 +77,89 @@ func (c *Client) DeleteSessions(startTimes []time.Time) error {
 	})
 }
 
+// taskKey returns the key of a task in the tasks bucket. Keys are big-endian
+// so that tasks are iterated in the order they were added.
+func taskKey(id int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id)) //nolint:gosec // IDs are positive
+}
+
+func (c *Client) AddTask(task *models.Task) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(taskBucket))
+
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		task.ID = int(id)
+
+		b, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(taskKey(task.ID), b)
+	})
+}
+
+func (c *Client) GetTask(id int) (*models.Task, error) {
+	var task models.Task
+
+	err := c.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(taskBucket)).Get(taskKey(id))
+		if b == nil {
+			return fmt.Errorf("%w: %d", errTaskNotFound, id)
+		}
+
+		return json.Unmarshal(b, &task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (c *Client) GetTasks() ([]*models.Task, error) {
+	var tasks []*models.Task
+
+	err := c.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(taskBucket)).ForEach(func(_, v []byte) error {
+			var task models.Task
+
+			err := json.Unmarshal(v, &task)
+			if err != nil {
+				return err
+			}
+
+			tasks = append(tasks, &task)
+
+			return nil
+		})
+	})
+
+	return tasks, err
+}
+
+func (c *Client) UpdateTask(task *models.Task) error {
+	return c.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(taskBucket))
+
+		key := taskKey(task.ID)
+		if bucket.Get(key) == nil {
+			return fmt.Errorf("%w: %d", errTaskNotFound, task.ID)
+		}
+
+		b, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(key, b)
+	})
+}
+
 func (c *Client) Open() error {
 	db, err := openDB(config.DBFilePath())
 	if err != nil {
@@ -78,7 +175,7 @@ func (c *Client) Open() error {
 
 func (c *Client) GetSessions(
 	since, until time.Time,
-	tags []string,
+	query *tagquery.Query,
 ) ([]*models.Session, error) {
 	var result []*models.Session
 
@@ -119,14 +216,8 @@ func (c *Client) GetSessions(
 				return err
 			}
 
-			// Filter out tags that don't match
-			if len(tags) != 0 {
-				for _, t := range sess.Tags {
-					if slices.Contains(tags, t) {
-						result = append(result, &sess)
-					}
-				}
-			} else {
+			// Filter out sessions whose tags don't match
+			if query.Match(sess.Tags) {
 				result = append(result, &sess)
 			}
 		}
@@ -147,8 +238,12 @@ func openDB(dbFilePath string) (*bolt.DB, error) {
 		&bolt.Options{Timeout: 1 * time.Second},
 	)
 
-	if err != nil && errors.Is(err, bolterr.ErrTimeout) {
-		return nil, errFocusRunning
+	if err != nil {
+		if errors.Is(err, bolterr.ErrTimeout) {
+			return nil, errFocusRunning
+		}
+
+		return nil, err
 	}
 
 	return db, nil
@@ -172,6 +267,11 @@ func NewClient(dbFilePath string) (*Client, error) {
 			return err
 		}
 
+		_, err = tx.CreateBucketIfNotExists([]byte(taskBucket))
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -0,0 +1,357 @@
+package timer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/stats"
+)
+
+// commandMsg is a line of input read in accessible mode.
+type commandMsg string
+
+const (
+	// helpCommand lists the commands available in accessible mode.
+	helpCommand = "?"
+
+	// enterKey is the key that an empty line stands for in accessible mode.
+	enterKey = "enter"
+)
+
+// accessible reports whether the timer prints state changes as plain lines
+// instead of drawing itself.
+func (t *Timer) accessible() bool {
+	return t.Opts.CLI.Accessible
+}
+
+// RunAccessible runs the timer without drawing it or styling its output. State
+// changes and the time remaining are printed as plain lines so that they can
+// be followed with a screen reader. Commands are the keys shown by the help
+// command (`?`) and are read from r a line at a time. An empty line stands for
+// the enter key.
+func (t *Timer) RunAccessible(r io.Reader) error {
+	p := tea.NewProgram(
+		t,
+		tea.WithoutRenderer(),
+		tea.WithInput(nil),
+		tea.WithoutSignalHandler(),
+	)
+
+	go func() {
+		scanner := bufio.NewScanner(r)
+
+		for scanner.Scan() {
+			p.Send(commandMsg(strings.TrimSpace(scanner.Text())))
+		}
+	}()
+
+	// the terminal isn't in raw mode, so Ctrl-C arrives as a signal
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	defer signal.Stop(interrupt)
+
+	go func() {
+		for range interrupt {
+			p.Send(tea.KeyMsg{Type: tea.KeyCtrlC})
+		}
+	}()
+
+	_, err := p.Run()
+	if err != nil {
+		return err
+	}
+
+	return t.Flush()
+}
+
+// handleCommand answers the pending question with the command, or otherwise
+// handles it like the key with the same name.
+func (t *Timer) handleCommand(cmd string) (tea.Model, tea.Cmd) {
+	if t.answer != nil {
+		answer := t.answer
+		t.answer = nil
+
+		return t, answer(cmd)
+	}
+
+	if cmd == helpCommand {
+		t.announceCommands()
+
+		return t, nil
+	}
+
+	if cmd == "" {
+		cmd = enterKey
+	}
+
+	return t.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(cmd)})
+}
+
+// ask prints a question in accessible mode. The next line of input is passed
+// to answer instead of being handled as a command.
+func (t *Timer) ask(question string, answer func(string) tea.Cmd) tea.Cmd {
+	t.answer = answer
+	t.announce("%s", question)
+
+	return nil
+}
+
+// announce prints a line in accessible mode.
+func (t *Timer) announce(format string, a ...any) {
+	if !t.accessible() {
+		return
+	}
+
+	fmt.Fprintf(config.Stdout, format+"\n", a...)
+}
+
+// spokenDuration formats a duration in words such as "1 hour 5 minutes".
+// Durations of a minute or more are rounded to the nearest minute.
+func spokenDuration(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, unit)
+		}
+
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	if d < time.Minute {
+		return plural(int(d.Round(time.Second).Seconds()), "second")
+	}
+
+	mins := int(d.Round(time.Minute).Minutes())
+	hrs, mins := mins/60, mins%60
+
+	switch {
+	case hrs == 0:
+		return plural(mins, "minute")
+	case mins == 0:
+		return plural(hrs, "hour")
+	}
+
+	return plural(hrs, "hour") + " " + plural(mins, "minute")
+}
+
+// spokenTimeFormat returns the layout used to announce times of day. Seconds
+// are left out.
+func (t *Timer) spokenTimeFormat() string {
+	return strings.Replace(t.timeFormat(), ":05", "", 1)
+}
+
+// progressText returns the time worked so far in a flowtime work session, and
+// the time remaining in other sessions.
+func (t *Timer) progressText() string {
+	if t.isFlow() {
+		return spokenDuration(t.flowElapsed()) + " worked"
+	}
+
+	return spokenDuration(t.clock.Timeout) + " remaining"
+}
+
+// announceStart announces the session that just started.
+func (t *Timer) announceStart() {
+	t.lastAnnounced = time.Now()
+
+	if t.isFlow() {
+		t.announce(
+			"%s started at %s, counting up.",
+			t.Current.Name,
+			t.Current.StartTime.Format(t.spokenTimeFormat()),
+		)
+
+		return
+	}
+
+	t.announce(
+		"%s started, %s, ends %s.",
+		t.Current.Name,
+		spokenDuration(t.Current.Duration),
+		t.Current.EndTime.Format(t.spokenTimeFormat()),
+	)
+}
+
+// announceProgress announces the time remaining in the session at the
+// configured interval while the clock is running.
+func (t *Timer) announceProgress() {
+	interval := t.Opts.Settings.AnnounceInterval
+
+	if !t.accessible() || interval == 0 || !t.running() ||
+		time.Since(t.lastAnnounced) < interval {
+		return
+	}
+
+	t.lastAnnounced = time.Now()
+
+	t.announce("%s.", capitalise(t.progressText()))
+}
+
+// announceCommands lists the commands that apply to the current state of the
+// timer. Switching the display mode has no effect in accessible mode.
+func (t *Timer) announceCommands() {
+	bindings := slices.DeleteFunc(t.helpBindings(), func(b key.Binding) bool {
+		return b.Help() == defaultKeymap.display.Help()
+	})
+
+	commands := make([]string, 0, len(bindings))
+
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+
+		commands = append(commands, b.Help().Key+" "+b.Help().Desc)
+	}
+
+	commands = append(commands, helpCommand+" help")
+
+	t.announce("Commands: %s.", strings.Join(commands, ", "))
+}
+
+// announceToday reads out the sessions saved today.
+func (t *Timer) announceToday() {
+	t.announce(
+		"Today: %s focused, %d sessions completed.",
+		stats.FormatDuration(t.today.Total),
+		t.today.Completed,
+	)
+
+	for _, sess := range t.today.Sessions {
+		status := "completed"
+		if !sess.Completed {
+			status = "abandoned"
+		}
+
+		tags := strings.Join(sess.Tags, ", ")
+		if tags == "" {
+			tags = "untagged"
+		}
+
+		t.announce(
+			"%s, %s, %s.",
+			sess.StartTime.Format(t.spokenTimeFormat()),
+			tags,
+			status,
+		)
+	}
+}
+
+// announceShutdown reads out the summary and checklist shown when the timer
+// stops for the day.
+func (t *Timer) announceShutdown() {
+	t.announce("That's a wrap for today. %s", t.summaryMessage())
+	t.announceToday()
+
+	for i, item := range t.Opts.Settings.ShutdownChecklist {
+		t.announce("Checklist item %d: %s.", i+1, item)
+	}
+
+	t.announce("Press Enter to exit.")
+}
+
+// askInterruptionReason asks for the reason for the interruption that was
+// just logged.
+func (t *Timer) askInterruptionReason(kind models.InterruptionKind) tea.Cmd {
+	return t.ask(
+		fmt.Sprintf(
+			"Logged %s interruption. Type a reason or press Enter to skip:",
+			kind,
+		),
+		func(answer string) tea.Cmd {
+			last := len(t.Current.Interruptions) - 1
+			t.Current.Interruptions[last].Reason = answer
+
+			return nil
+		},
+	)
+}
+
+// askNotes asks what was achieved in the work session that just ended and
+// starts the next session once it is answered.
+func (t *Timer) askNotes() tea.Cmd {
+	return t.ask(
+		"What did you get done? Press Enter to skip:",
+		func(answer string) tea.Cmd {
+			if answer != "" {
+				t.Current.Notes = answer
+				_ = t.persist()
+			}
+
+			_ = t.postSession()
+
+			return t.initSession()
+		},
+	)
+}
+
+// askTags asks for new tags for the current session and whether the sessions
+// that follow should get them too.
+func (t *Timer) askTags() tea.Cmd {
+	current := strings.Join(t.Current.Tags, tagSeparator+" ")
+	if current == "" {
+		current = "none"
+	}
+
+	return t.ask(
+		fmt.Sprintf("Current tags: %s. Type the new tags separated by commas:", current),
+		func(answer string) tea.Cmd {
+			tags := parseTags(answer)
+			t.Current.Tags = tags
+
+			return t.ask(
+				"Apply to the next sessions too? Type y for yes:",
+				func(answer string) tea.Cmd {
+					if strings.EqualFold(answer, "y") {
+						t.Opts.CLI.Tags = tags
+					}
+
+					_ = t.writeStatusFile()
+
+					t.announce("Tags changed.")
+
+					return nil
+				},
+			)
+		},
+	)
+}
+
+// askSound asks for the ambient sound to play.
+func (t *Timer) askSound() tea.Cmd {
+	return t.ask(
+		fmt.Sprintf(
+			"Type one of %s, or press Enter to turn off the ambient sound:",
+			strings.Join(config.SoundOpts(), ", "),
+		),
+		func(answer string) tea.Cmd {
+			t.Opts.Settings.AmbientSound = answer
+
+			if err := t.setAmbientSound(); err != nil {
+				t.announce("%s", err)
+			}
+
+			return nil
+		},
+	)
+}
+
+// capitalise returns s with its first letter in upper case.
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,184 @@
+package timer
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// bigGlyphs are the large characters used to display the time in full-screen
+// mode. Each `#` is drawn as a block that is two cells wide so that the glyphs
+// look square in most terminal fonts.
+var bigGlyphs = map[rune][]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {" ", "#", " ", "#", " "},
+}
+
+// bigText renders the digits and colons in s with large block characters.
+func bigText(s string) string {
+	rows := make([]string, len(bigGlyphs['0']))
+
+	for i := range rows {
+		var row strings.Builder
+
+		for j, r := range s {
+			glyph, ok := bigGlyphs[r]
+			if !ok {
+				continue
+			}
+
+			if j > 0 {
+				row.WriteString("  ")
+			}
+
+			row.WriteString(
+				strings.NewReplacer("#", "██", " ", "  ").Replace(glyph[i]),
+			)
+		}
+
+		rows[i] = row.String()
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// clockText returns the time shown by the timer: the time worked so far in a
+// flowtime work session, and the time remaining otherwise.
+func (t *Timer) clockText() string {
+	if t.isFlow() {
+		return t.formatTimeElapsed()
+	}
+
+	return t.formatTimeRemaining()
+}
+
+// sessionStyle returns the style of the current session.
+func (t *Timer) sessionStyle() lipgloss.Style {
+	var sessStyle lipgloss.Style
+
+	switch t.Current.Name {
+	case config.Work:
+		sessStyle = defaultStyle.work
+	case config.ShortBreak:
+		sessStyle = defaultStyle.shortBreak
+	case config.LongBreak:
+		sessStyle = defaultStyle.longBreak
+	}
+
+	if step, ok := t.sequenceStep(); ok && step.Message != "" {
+		sessStyle = sessStyle.SetString(step.Message)
+	}
+
+	return sessStyle
+}
+
+// positionText returns the position of the current session in the selected
+// preset, or in the cycle of work sessions before a long break. It is empty
+// for sessions outside a preset that are not regular work sessions.
+func (t *Timer) positionText() string {
+	if _, ok := t.sequenceStep(); ok {
+		return fmt.Sprintf(
+			"%s %d/%d",
+			t.Opts.CLI.Preset,
+			t.step+1,
+			len(t.Opts.CLI.Sequence),
+		)
+	}
+
+	if t.Current.Name == config.Work && !t.Opts.CLI.Flow {
+		return fmt.Sprintf(
+			"%d/%d",
+			t.WorkCycle,
+			t.Opts.Settings.LongBreakInterval,
+		)
+	}
+
+	return ""
+}
+
+// paused reports whether the current session is paused.
+func (t *Timer) paused() bool {
+	return !t.running() && !t.timedout()
+}
+
+// toggleDisplay switches to the next display mode. The full-screen mode uses
+// the alternate screen buffer so that the terminal is restored when leaving it.
+func (t *Timer) toggleDisplay() tea.Cmd {
+	i := slices.Index(config.DisplayModes, t.display)
+	t.display = config.DisplayModes[(i+1)%len(config.DisplayModes)]
+
+	switch t.display {
+	case config.DisplayFullscreen:
+		return tea.EnterAltScreen
+	case config.DisplayCompact:
+		return tea.ExitAltScreen
+	}
+
+	return nil
+}
+
+// fullscreenView renders the time in large digits centered in the terminal,
+// with the session message and state below it.
+func (t *Timer) fullscreenView() string {
+	sessStyle := t.sessionStyle()
+
+	status := sessStyle.Render()
+	if t.paused() {
+		status += "[Paused]"
+	}
+
+	view := t.withToday(lipgloss.JoinVertical(
+		lipgloss.Center,
+		sessStyle.UnsetString().Render(bigText(t.clockText())),
+		"",
+		status,
+		t.helpView(),
+	))
+
+	if t.width == 0 || t.height == 0 {
+		return view
+	}
+
+	return lipgloss.Place(
+		t.width,
+		t.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		view,
+	)
+}
+
+// compactView renders the session and time on a single line for small
+// terminal panes.
+func (t *Timer) compactView() string {
+	line := t.sessionStyle().Render() + t.clockText()
+
+	if t.paused() {
+		line += " [Paused]"
+	}
+
+	if position := t.positionText(); position != "" {
+		line += " (" + position + ")"
+	}
+
+	return line
+}
+
+// minimalView renders the time and nothing else.
+func (t *Timer) minimalView() string {
+	return t.clockText()
+}
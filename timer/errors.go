@@ -14,4 +14,20 @@ var (
 	errStrictMode = &apperr.Error{
 		Message: "session resumption failed: strict mode is enabled",
 	}
+
+	errTaskDone = &apperr.Error{
+		Message: "task %d is already done",
+	}
+
+	errNoOpenTasks = &apperr.Error{
+		Message: "there are no open tasks to pick from in %s",
+	}
+
+	errSessionsNotSaved = &apperr.Error{
+		Message: "%d session(s) could not be saved: %v",
+	}
+
+	errTimerRunning = &apperr.Error{
+		Message: "is Focus already running? Only one timer can be active at a time",
+	}
 )
@@ -0,0 +1,50 @@
+package timer
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// quitKey always quits the timer so that it can't be left without a way out.
+const quitKey = "ctrl+c"
+
+// newBinding returns a binding for keys which shows the first key in the help
+// text. It is disabled if there are no keys.
+func newBinding(keys []string, desc string) key.Binding {
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(keys[0], desc),
+	)
+}
+
+// newKeymap returns the key bindings from the keys section of the config.
+func newKeymap(keys config.KeysConfig) keymap {
+	quit := keys.Quit
+	if !slices.Contains(quit, quitKey) {
+		quit = slices.Concat(quit, []string{quitKey})
+	}
+
+	return keymap{
+		togglePlay:        newBinding(keys.PlayPause, "play/pause"),
+		sound:             newBinding(keys.Sound, "sound"),
+		internalInterrupt: newBinding(keys.InternalInterruption, "internal interruption"),
+		externalInterrupt: newBinding(keys.ExternalInterruption, "external interruption"),
+		enter:             newBinding(keys.Continue, "continue"),
+		finish:            newBinding(keys.Finish, "finish"),
+		finishEarly:       newBinding(keys.FinishEarly, "finish early"),
+		extend:            newBinding(keys.Extend, "extend"),
+		snooze:            newBinding(keys.Snooze, "snooze"),
+		display:           newBinding(keys.Display, "display"),
+		today:             newBinding(keys.Today, "today"),
+		tags:              newBinding(keys.Tags, "tags"),
+		quit:              newBinding(quit, "quit"),
+		esc:               newBinding(keys.Skip, "skip"),
+	}
+}
@@ -20,13 +20,16 @@ type (
 
 	// Session represents an active work or break session.
 	Session struct {
-		StartTime time.Time          `json:"start_time"`
-		EndTime   time.Time          `json:"end_time"`
-		Name      config.SessionType `json:"name"`
-		Tags      []string           `json:"tags"`
-		Timeline  []Timeline         `json:"timeline"`
-		Duration  time.Duration      `json:"duration"`
-		Completed bool               `json:"completed"`
+		StartTime     time.Time             `json:"start_time"`
+		EndTime       time.Time             `json:"end_time"`
+		Name          config.SessionType    `json:"name"`
+		Tags          []string              `json:"tags"`
+		Notes         string                `json:"notes"`
+		Timeline      []Timeline            `json:"timeline"`
+		Interruptions []models.Interruption `json:"interruptions"`
+		Duration      time.Duration         `json:"duration"`
+		TaskID        int                   `json:"task_id"`
+		Completed     bool                  `json:"completed"`
 	}
 
 	// Remainder is the time remaining in an active session.
@@ -111,6 +114,32 @@ func (s *Session) ElapsedTimeInSeconds() float64 {
 	return elapsedTimeInSeconds
 }
 
+// Elapsed returns the time elapsed for the current session rounded to the
+// nearest second.
+func (s *Session) Elapsed() time.Duration {
+	elapsed := time.Duration(s.ElapsedTimeInSeconds() * float64(time.Second))
+
+	return elapsed.Round(time.Second)
+}
+
+// Resume starts a new part of the timeline of a flowtime session that was
+// paused. A flowtime session has no predetermined end, so its end time is
+// updated when it is paused or finished.
+func (s *Session) Resume() {
+	last := s.Timeline[len(s.Timeline)-1]
+	if !last.EndTime.After(last.StartTime) {
+		return
+	}
+
+	now := time.Now()
+
+	s.EndTime = now
+	s.Timeline = append(s.Timeline, Timeline{
+		StartTime: now,
+		EndTime:   now,
+	})
+}
+
 // RealElapsedTimeInSeconds returns the time elapsed for the current session
 // in seconds using real timings.
 func (s *Session) RealElapsedTimeInSeconds() float64 {
@@ -189,7 +218,10 @@ func (s *Session) ToDBModel() *models.Session {
 	sess.EndTime = s.EndTime
 	sess.Name = s.Name
 	sess.Tags = s.Tags
+	sess.Notes = s.Notes
+	sess.Interruptions = s.Interruptions
 	sess.Duration = s.Duration
+	sess.TaskID = s.TaskID
 	sess.Completed = s.Completed
 
 	for _, v := range s.Timeline {
@@ -0,0 +1,160 @@
+package timer
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/gitrepo"
+	"github.com/ayoisaiah/focus/store"
+)
+
+// tagSeparator separates the tags entered in the tags prompt.
+const tagSeparator = ","
+
+// parseTags splits a comma-separated list of tags, dropping blank and
+// duplicate tags.
+func parseTags(s string) []string {
+	var tags []string
+
+	for _, tag := range strings.Split(s, tagSeparator) {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// tagSuggestions completes the last tag in value with each of the known tags.
+func tagSuggestions(value string, known []string) []string {
+	prefix := ""
+
+	// keep the tags before the last one as typed so that the suggestions
+	// match the value of the input
+	if i := strings.LastIndex(value, tagSeparator); i >= 0 {
+		last := value[i+1:]
+		prefix = value[:i+1] + last[:len(last)-len(strings.TrimLeft(last, " "))]
+	}
+
+	suggestions := make([]string, 0, len(known))
+
+	for _, tag := range known {
+		if !slices.Contains(parseTags(prefix), tag) {
+			suggestions = append(suggestions, prefix+tag)
+		}
+	}
+
+	return suggestions
+}
+
+// sessionTags returns the tags for a session that is starting: the tags that
+// the timer was started with, followed by those of the git repository that it
+// is run in if auto-tagging is enabled. The repository is detected afresh for
+// each session so that a change of branch is picked up.
+func (t *Timer) sessionTags() []string {
+	if !t.Opts.GitTagsEnabled() {
+		return t.Opts.CLI.Tags
+	}
+
+	dir := t.Opts.CLI.Repo
+	if dir == "" {
+		dir = "."
+	}
+
+	repo, err := gitrepo.Find(dir)
+	if err != nil {
+		return t.Opts.CLI.Tags
+	}
+
+	return mergeTags(slices.Clone(t.Opts.CLI.Tags), t.Opts.Git.GitTags(repo))
+}
+
+// mergeTags appends the tags in more that are not already in tags.
+func mergeTags(tags, more []string) []string {
+	for _, tag := range more {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+// knownTags returns the tags of every saved session in alphabetical order.
+func (t *Timer) knownTags() []string {
+	var tags []string
+
+	_ = store.Use(t.db, func() error {
+		sessions, err := t.db.GetSessions(time.Time{}, time.Now(), nil)
+		if err != nil {
+			return err
+		}
+
+		for _, sess := range sessions {
+			tags = append(tags, sess.Tags...)
+		}
+
+		return nil
+	})
+
+	slices.Sort(tags)
+
+	return slices.Compact(tags)
+}
+
+// promptTags asks for new tags for the current session without pausing the
+// timer. Previously used tags are suggested as they are typed.
+func (t *Timer) promptTags() tea.Cmd {
+	if t.accessible() {
+		return t.askTags()
+	}
+
+	known := t.knownTags()
+	value := strings.Join(t.Current.Tags, tagSeparator+" ")
+
+	t.tagsForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("tags").
+				Value(&value).
+				Title("Tags").
+				Description("Separate tags with commas. Press ctrl+c to cancel").
+				SuggestionsFunc(func() []string {
+					return tagSuggestions(value, known)
+				}, &value),
+			huh.NewConfirm().
+				Key("next").
+				Title("Apply to the next sessions too?").
+				Affirmative("Yes").
+				Negative("No"),
+		),
+	)
+	t.settings = tagsView
+
+	return t.tagsForm.Init()
+}
+
+// closeTagsForm changes the tags of the current session, and of the sessions
+// that follow if requested, once the prompt is submitted. It then dismisses
+// the prompt. The new tags are saved along with the rest of the session.
+func (t *Timer) closeTagsForm() {
+	if t.tagsForm.State == huh.StateCompleted {
+		tags := parseTags(t.tagsForm.GetString("tags"))
+
+		t.Current.Tags = tags
+
+		if t.tagsForm.GetBool("next") {
+			t.Opts.CLI.Tags = tags
+		}
+
+		_ = t.writeStatusFile()
+	}
+
+	t.tagsForm = nil
+	t.settings = ""
+}
@@ -0,0 +1,106 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/tasksource"
+)
+
+// pickerHeight is the number of lines taken up by the task picker.
+const pickerHeight = 12
+
+// pickSourceTask asks which of the open tasks in the configured task source
+// the timer is started for. Tasks can be filtered by typing `/`.
+func pickSourceTask(
+	cfg *config.Config,
+) (tasksource.Source, *tasksource.Task, error) {
+	source, err := cfg.TaskSource.Source()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tasks, err := source.Tasks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, nil, errNoOpenTasks.Fmt(cfg.TaskSource.Type)
+	}
+
+	options := make([]huh.Option[int], 0, len(tasks))
+
+	for i, task := range tasks {
+		options = append(options, huh.NewOption(taskLabel(task), i))
+	}
+
+	var choice int
+
+	err = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("What are you working on?").
+				Options(options...).
+				Height(pickerHeight).
+				Value(&choice),
+		),
+	).WithAccessible(cfg.CLI.Accessible).Run()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return source, &tasks[choice], nil
+}
+
+// taskLabel describes a task in the task picker.
+func taskLabel(task tasksource.Task) string {
+	var s strings.Builder
+
+	if task.Priority != "" {
+		fmt.Fprintf(&s, "(%s) ", task.Priority)
+	}
+
+	s.WriteString(task.Description)
+
+	for _, tag := range task.Tags() {
+		s.WriteString(" #" + tag)
+	}
+
+	return s.String()
+}
+
+// updateSourceTask marks the picked task as done or adds a note to it once a
+// work session is completed, as set in the config file. A task that is done
+// is not updated again, but the sessions that follow keep its tags. A
+// snoozed session was already counted when it first ended.
+func (t *Timer) updateSourceTask() error {
+	if t.sourceTask == nil || t.Current.Name != config.Work || t.snoozed {
+		return nil
+	}
+
+	switch t.Opts.TaskSource.OnComplete {
+	case config.TaskActionDone:
+		err := t.source.Done(*t.sourceTask)
+		if err != nil {
+			return err
+		}
+
+		t.announce("Marked %s as done.", t.sourceTask.Description)
+
+		t.sourceTask = nil
+	case config.TaskActionNote:
+		note := "Focused for " + spokenDuration(t.Current.Duration)
+		if t.Current.Notes != "" {
+			note += ": " + t.Current.Notes
+		}
+
+		return t.source.Annotate(*t.sourceTask, note)
+	case config.TaskActionNone:
+	}
+
+	return nil
+}
@@ -8,16 +8,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/stopwatch"
 	btimer "github.com/charmbracelet/bubbles/timer"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
@@ -27,12 +28,14 @@ import (
 	"github.com/gen2brain/beeep"
 	"github.com/kballard/go-shellquote"
 	"github.com/pterm/pterm"
-	bolt "go.etcd.io/bbolt"
-	bolterr "go.etcd.io/bbolt/errors"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/lockfile"
 	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tasksource"
+	"github.com/ayoisaiah/focus/internal/timeutil"
 	"github.com/ayoisaiah/focus/report"
+	"github.com/ayoisaiah/focus/stats"
 	"github.com/ayoisaiah/focus/store"
 )
 
@@ -46,102 +49,222 @@ type (
 
 	// Timer represents a running timer.
 	Timer struct {
-		help               help.Model
-		StartTime          time.Time      `json:"start_time"`
-		SessionKey         time.Time      `json:"session_key"`
-		PausedTime         time.Time      `json:"paused_time"`
-		SoundStream        beep.Streamer  `json:"-"`
-		db                 store.DB       `json:"-"`
+		help        help.Model
+		StartTime   time.Time     `json:"start_time"`
+		SessionKey  time.Time     `json:"session_key"`
+		PausedTime  time.Time     `json:"paused_time"`
+		SoundStream beep.Streamer `json:"-"`
+		db          store.DB      `json:"-"`
+		// lock is held until the timer is closed so that other commands can
+		// tell that the timer is running.
+		lock               *lockfile.Lock
 		Opts               *config.Config `json:"opts"`
 		Current            *Session
 		soundForm          *huh.Form
+		notesForm          *huh.Form
+		interruptionForm   *huh.Form
+		shutdownForm       *huh.Form
+		tagsForm           *huh.Form
 		S                  S
 		settings           settingsView
+		display            config.DisplayMode
 		progress           progress.Model
 		clock              btimer.Model
+		stopwatch          stopwatch.Model
+		goals              stats.Goals
+		today              stats.DaySummary
+		task               *models.Task
+		taskPomodoros      int
+		source             tasksource.Source
+		sourceTask         *tasksource.Task
+		flowBreak          time.Duration
+		unsaved            map[time.Time]*models.Session
+		saveErr            error
+		answer             func(string) tea.Cmd
+		lastAnnounced      time.Time
 		WorkCycle          int `json:"work_cycle"`
+		width              int
+		height             int
+		completed          int
+		step               int
 		waitForNextSession bool
+		snoozed            bool
+		showToday          bool
+		retryPending       bool
 	}
 
+	// saveRetryMsg is sent when saving the unsaved sessions should be
+	// retried.
+	saveRetryMsg struct{}
+
 	keymap struct {
-		togglePlay key.Binding
-		sound      key.Binding
-		enter      key.Binding
-		quit       key.Binding
-		esc        key.Binding
+		togglePlay        key.Binding
+		sound             key.Binding
+		internalInterrupt key.Binding
+		externalInterrupt key.Binding
+		enter             key.Binding
+		finish            key.Binding
+		finishEarly       key.Binding
+		extend            key.Binding
+		snooze            key.Binding
+		display           key.Binding
+		today             key.Binding
+		tags              key.Binding
+		quit              key.Binding
+		esc               key.Binding
 	}
 
 	style struct {
 		work       lipgloss.Style
 		shortBreak lipgloss.Style
 		longBreak  lipgloss.Style
+		accent     lipgloss.Style
+		success    lipgloss.Style
+		error      lipgloss.Style
 		base       lipgloss.Style
+		muted      lipgloss.Style
 		help       lipgloss.Style
+		panel      lipgloss.Style
 	}
 )
 
 const (
 	padding  = 2
 	maxWidth = 80
+
+	maxReasonLength = 80
+
+	// minPlannedSession is the shortest session that is started when sessions
+	// must end by the time specified with --until.
+	minPlannedSession = time.Minute
+
+	// saveRetryInterval is how often saving a session is retried when the
+	// database is locked by another Focus command.
+	saveRetryInterval = 5 * time.Second
+
+	// flushAttempts is the number of times saving is retried when the timer
+	// exits.
+	flushAttempts = 3
 )
 
 var (
 	defaultStyle  style
-	defaultKeymap = keymap{
-		togglePlay: key.NewBinding(
-			key.WithKeys("p"),
-			key.WithHelp("p", "play/pause"),
-		),
-		sound: key.NewBinding(
-			key.WithKeys("s"),
-			key.WithHelp("s", "sound"),
-		),
-		enter: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp(
-				"enter",
-				"continue",
-			),
-		),
-		quit: key.NewBinding(
-			key.WithKeys("ctrl+c", "q"),
-			key.WithHelp("q", "quit"),
-		),
-		esc: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "skip"),
-		),
-	}
+	defaultKeymap keymap
 )
 
-var soundView settingsView = "sound"
+var (
+	soundView        settingsView = "sound"
+	notesView        settingsView = "notes"
+	interruptionView settingsView = "interruption"
+	shutdownView     settingsView = "shutdown"
+	tagsView         settingsView = "tags"
+)
 
-// New creates a new timer.
+// New creates a new timer. The database connection is released until it is
+// needed so that other processes can read from it while the timer is running.
 func New(dbClient store.DB, cfg *config.Config) (*Timer, error) {
+	if config.TimerRunning() {
+		return nil, errTimerRunning
+	}
+
+	var task *models.Task
+
+	if cfg.CLI.TaskID != 0 {
+		var err error
+
+		task, err = dbClient.GetTask(cfg.CLI.TaskID)
+		if err != nil {
+			return nil, err
+		}
+
+		if task.Done() {
+			return nil, errTaskDone.Fmt(task.ID)
+		}
+
+		cfg.CLI.Tags = mergeTags(cfg.CLI.Tags, task.Tags)
+	}
+
+	var (
+		source     tasksource.Source
+		sourceTask *tasksource.Task
+	)
+
+	if cfg.PickTaskEnabled() {
+		var err error
+
+		source, sourceTask, err = pickSourceTask(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.CLI.Tags = mergeTags(cfg.CLI.Tags, sourceTask.Tags())
+	}
+
+	err := dbClient.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// the check above is repeated here since another timer may have been
+	// started while a task was being picked
+	lock, err := lockfile.Acquire(config.LockFilePath())
+	if errors.Is(err, lockfile.ErrLocked) {
+		return nil, errTimerRunning
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defaultKeymap = newKeymap(cfg.Keys)
+
+	palette := cfg.Theme
+
 	defaultStyle = style{
 		work: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.Work.Color)).
+			Foreground(lipgloss.Color(palette.Work)).
 			MarginRight(1).
 			SetString(cfg.Work.Message),
 		shortBreak: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.ShortBreak.Color)).
+			Foreground(lipgloss.Color(palette.ShortBreak)).
 			MarginRight(1).
 			SetString(cfg.ShortBreak.Message),
 		longBreak: lipgloss.NewStyle().
-			Foreground(lipgloss.Color(cfg.LongBreak.Color)).
+			Foreground(lipgloss.Color(palette.LongBreak)).
 			MarginRight(1).
 			SetString(cfg.LongBreak.Message),
+		accent: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Accent)),
+		success: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Success)),
+		error: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Error)),
 		base: lipgloss.NewStyle().Padding(1, 1),
+		muted: lipgloss.NewStyle().
+			Foreground(lipgloss.Color(palette.Muted)),
 		help: lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240")).
+			Foreground(lipgloss.Color(palette.Muted)).
 			MarginTop(2),
+		panel: lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(lipgloss.Color(palette.Muted)).
+			MarginLeft(4).
+			PaddingLeft(2).
+			MaxWidth(todayPanelWidth),
 	}
 
 	t := &Timer{
-		db:       dbClient,
-		Opts:     cfg,
-		help:     help.New(),
-		progress: progress.New(progress.WithDefaultGradient()),
+		db:         dbClient,
+		lock:       lock,
+		task:       task,
+		source:     source,
+		sourceTask: sourceTask,
+		Opts:       cfg,
+		display:    cfg.Display.Mode,
+		help:       help.New(),
+		progress: progress.New(
+			progress.WithGradient(palette.ProgressStart, palette.ProgressEnd),
+		),
 		S: S{
 			config.Work: {
 				Duration: cfg.Work.Duration,
@@ -158,13 +281,30 @@ func New(dbClient store.DB, cfg *config.Config) (*Timer, error) {
 		},
 	}
 
-	err := t.setAmbientSound()
+	err = t.setAmbientSound()
+	if err != nil {
+		_ = t.Close()
+
+		return nil, err
+	}
 
-	return t, err
+	return t, nil
 }
 
-// sessions added with the --since flag.
+// sessions added with the --since flag. If the first session is scheduled to
+// start later, Init counts down to its start time instead.
 func (t *Timer) Init() tea.Cmd {
+	if t.Opts.CLI.At.After(time.Now()) {
+		t.clock = btimer.New(time.Until(t.Opts.CLI.At).Round(time.Second))
+
+		t.announce(
+			"The first session starts at %s.",
+			t.Opts.CLI.At.Format(t.spokenTimeFormat()),
+		)
+
+		return t.clock.Init()
+	}
+
 	t.StartTime = time.Now()
 
 	err := t.new()
@@ -180,7 +320,22 @@ func (t *Timer) Init() tea.Cmd {
 		return report.Fatal(err)
 	}
 
-	return t.clock.Init()
+	t.updateGoals()
+	t.updateTask()
+	t.updateToday()
+
+	if t.limitReached() {
+		return t.shutdown()
+	}
+
+	t.announceStart()
+	t.announceCommands()
+
+	if t.display == config.DisplayFullscreen {
+		return tea.Batch(tea.EnterAltScreen, t.startClock())
+	}
+
+	return t.startClock()
 }
 
 // new creates a new timer.
@@ -192,23 +347,112 @@ func (t *Timer) new() error {
 
 	t.Current = sess
 	t.WorkCycle = 1
-	t.clock = btimer.New(t.Current.Duration)
 
 	return nil
 }
 
+// scheduled reports whether the timer is waiting for the first session to
+// start at the time specified with --at.
+func (t *Timer) scheduled() bool {
+	return t.Current == nil
+}
+
+// untilReached reports whether there is too little time left to start another
+// session before the time specified with --until.
+func (t *Timer) untilReached() bool {
+	until := t.Opts.CLI.Until
+
+	return !until.IsZero() && time.Until(until) < minPlannedSession
+}
+
+// isFlow reports whether the current session is a flowtime work session which
+// counts up until it is finished instead of counting down.
+func (t *Timer) isFlow() bool {
+	return t.Opts.CLI.Flow && t.Current.Name == config.Work
+}
+
+// running reports whether the clock of the current session is running.
+func (t *Timer) running() bool {
+	if t.isFlow() {
+		return t.stopwatch.Running()
+	}
+
+	return t.clock.Running()
+}
+
+// timedout reports whether the current session has run its course or was
+// finished early. A flowtime work session ends only when it is finished by the
+// user.
+func (t *Timer) timedout() bool {
+	if t.Current.Completed {
+		return true
+	}
+
+	if t.isFlow() {
+		return false
+	}
+
+	return t.clock.Timedout()
+}
+
+// flowElapsed returns the time worked so far in a flowtime work session.
+func (t *Timer) flowElapsed() time.Duration {
+	elapsed := t.Current.Elapsed()
+
+	if t.stopwatch.Running() {
+		last := t.Current.Timeline[len(t.Current.Timeline)-1]
+		elapsed += time.Since(last.StartTime).Round(time.Second)
+	}
+
+	return elapsed
+}
+
+// startClock creates a clock for the current session and starts it. Flowtime
+// work sessions use a stopwatch while every other session uses a countdown.
+func (t *Timer) startClock() tea.Cmd {
+	if t.isFlow() {
+		t.stopwatch = stopwatch.NewWithInterval(time.Second)
+
+		return t.stopwatch.Init()
+	}
+
+	t.clock = btimer.New(t.Current.Duration)
+
+	return t.clock.Init()
+}
+
 // newSession creates a new session.
 func (t *Timer) newSession(
 	name config.SessionType,
 ) *Session {
 	duration := t.S[name].Duration
+
+	if step, ok := t.sequenceStep(); ok && step.Duration > 0 {
+		duration = step.Duration
+	}
+
+	// the last session is shortened so that it ends on time
+	if until := t.Opts.CLI.Until; !until.IsZero() {
+		duration = min(duration, time.Until(until).Round(time.Second))
+	}
+
+	if t.Opts.CLI.Flow {
+		switch name {
+		case config.Work:
+			duration = 0
+		case config.ShortBreak, config.LongBreak:
+			duration = t.flowBreak
+		}
+	}
+
 	startTime := time.Now()
 	endTime := startTime.Add(duration)
 
 	return &Session{
 		Name:      name,
 		Duration:  duration,
-		Tags:      t.Opts.CLI.Tags,
+		Tags:      t.sessionTags(),
+		TaskID:    t.Opts.CLI.TaskID,
 		Completed: false,
 		StartTime: startTime,
 		EndTime:   endTime,
@@ -226,9 +470,14 @@ func (t *Timer) newSession(
 func (t *Timer) nextSession(current config.SessionType) config.SessionType {
 	var next config.SessionType
 
+	if seq := t.Opts.CLI.Sequence; len(seq) > 0 {
+		return seq[t.nextStep()].Type()
+	}
+
 	switch current {
 	case config.Work:
-		if t.WorkCycle == t.Opts.Settings.LongBreakInterval {
+		if !t.Opts.CLI.Flow &&
+			t.WorkCycle == t.Opts.Settings.LongBreakInterval {
 			next = config.LongBreak
 		} else {
 			next = config.ShortBreak
@@ -240,20 +489,60 @@ func (t *Timer) nextSession(current config.SessionType) config.SessionType {
 	return next
 }
 
+// sequenceStep returns the step of the selected preset that the current
+// session belongs to. It reports false if no preset is selected.
+func (t *Timer) sequenceStep() (config.SequenceStep, bool) {
+	if len(t.Opts.CLI.Sequence) == 0 {
+		return config.SequenceStep{}, false
+	}
+
+	return t.Opts.CLI.Sequence[t.step], true
+}
+
+// nextStep returns the position of the next session in the selected preset.
+// The sequence starts over after its last session.
+func (t *Timer) nextStep() int {
+	return (t.step + 1) % len(t.Opts.CLI.Sequence)
+}
+
+// startSession replaces the current session with a new session of the
+// specified type and starts its clock.
+func (t *Timer) startSession(name config.SessionType) tea.Cmd {
+	if t.untilReached() {
+		return t.shutdown()
+	}
+
+	if len(t.Opts.CLI.Sequence) > 0 {
+		t.step = t.nextStep()
+	}
+
+	t.Current = t.newSession(name)
+	t.snoozed = false
+
+	t.announceStart()
+
+	return t.startClock()
+}
+
 // initSession prepares the next session based on the current session type.
 // It handles work cycle counting, session creation, and auto-start settings.
 // Returns a tea.Cmd for initializing the timer if auto-start is enabled.
 func (t *Timer) initSession() tea.Cmd {
+	if t.untilReached() ||
+		t.Current.Name == config.Work && t.limitReached() {
+		return t.shutdown()
+	}
+
 	sessName := t.nextSession(t.Current.Name)
-	newSess := t.newSession(sessName)
 
-	if newSess.Name == config.Work && !t.Opts.Settings.AutoStartWork ||
-		newSess.Name != config.Work && !t.Opts.Settings.AutoStartBreak {
+	if sessName == config.Work && !t.Opts.Settings.AutoStartWork ||
+		sessName != config.Work && !t.Opts.Settings.AutoStartBreak {
 		t.waitForNextSession = true
 	}
 
-	// increment or reset the work cycle accordingly
-	if sessName == config.Work {
+	// increment or reset the work cycle accordingly. A snoozed session was
+	// already counted when it first ended.
+	if sessName == config.Work && !t.snoozed {
 		if t.WorkCycle == t.Opts.Settings.LongBreakInterval {
 			t.WorkCycle = 1
 		} else {
@@ -262,12 +551,15 @@ func (t *Timer) initSession() tea.Cmd {
 	}
 
 	if !t.waitForNextSession {
-		t.Current = newSess
-
-		t.clock = btimer.New(t.Current.Duration)
-		return t.clock.Init()
+		return t.startSession(sessName)
 	}
 
+	t.announce(
+		"Next up: %s. Press Enter to start or type %s for commands.",
+		sessName,
+		helpCommand,
+	)
+
 	return nil
 }
 
@@ -280,6 +572,13 @@ func (t *Timer) createSession() (*Session, error) {
 	if !t.Opts.CLI.StartTime.IsZero() {
 		sess.Adjust(t.Opts.CLI.StartTime)
 
+		// a flowtime session started in the past counts up from its start time
+		if t.Opts.CLI.Flow {
+			sess.Timeline[0].StartTime = sess.StartTime
+
+			return sess, nil
+		}
+
 		if time.Now().After(sess.EndTime) {
 			t.Current = sess
 
@@ -295,6 +594,236 @@ func (t *Timer) createSession() (*Session, error) {
 	return sess, nil
 }
 
+// completeSession saves the session that just ended and moves on to the next
+// one, asking for notes first if the notes prompt is enabled.
+func (t *Timer) completeSession() tea.Cmd {
+	if t.settings == interruptionView {
+		t.closeInterruptionForm()
+	}
+
+	if t.settings == tagsView {
+		t.closeTagsForm()
+	}
+
+	// an unanswered question no longer applies to the session
+	t.answer = nil
+
+	t.announce("%s completed.", t.Current.Name)
+
+	_ = t.persist()
+
+	// the end of a snoozed session was handled when it first ended, so only
+	// the next session is prepared
+	if t.snoozed {
+		return t.initSession()
+	}
+
+	if t.Current.Name == config.Work {
+		t.completed++
+	}
+
+	t.updateGoals()
+	t.updateTask()
+
+	if t.Current.Name == config.Work && t.Opts.Settings.NotesPrompt {
+		return t.promptNotes()
+	}
+
+	_ = t.postSession()
+
+	return t.initSession()
+}
+
+// finishFlow ends the flowtime work session and earns a break in proportion
+// to the time worked.
+func (t *Timer) finishFlow() tea.Cmd {
+	if t.stopwatch.Running() {
+		t.Current.UpdateEndTime(true)
+	} else {
+		t.Current.Completed = true
+	}
+
+	t.Current.Duration = t.Current.Elapsed()
+
+	t.flowBreak = t.Opts.Flow.BreakFor(t.Current.Duration)
+
+	return tea.Batch(t.stopwatch.Stop(), t.completeSession())
+}
+
+// extend lengthens the current work session by the configured increment. The
+// end of a paused session is recalculated from its duration when it resumes.
+func (t *Timer) extend() {
+	increment := t.Opts.Settings.Extend
+
+	t.Current.Duration += increment
+	t.clock.Timeout += increment
+
+	if t.clock.Running() {
+		t.Current.EndTime = t.Current.EndTime.Add(increment)
+
+		last := len(t.Current.Timeline) - 1
+		t.Current.Timeline[last].EndTime = t.Current.EndTime
+	}
+}
+
+// finishEarly ends the current work session before its time is up and counts
+// it as completed. The duration of the session becomes the time worked.
+func (t *Timer) finishEarly() tea.Cmd {
+	if t.clock.Running() {
+		t.Current.UpdateEndTime(true)
+	} else {
+		t.Current.Completed = true
+	}
+
+	t.Current.Duration = t.Current.Elapsed()
+
+	// discard the clock so that its remaining ticks are ignored
+	t.clock = btimer.New(0)
+
+	return t.completeSession()
+}
+
+// snooze postpones the next session by reopening the session that just ended
+// for the configured number of minutes. The extra time is added to the session
+// timeline as a new part.
+func (t *Timer) snooze() tea.Cmd {
+	snooze := t.Opts.Settings.Snooze
+	now := time.Now()
+
+	t.waitForNextSession = false
+	t.snoozed = true
+
+	t.Current.Completed = false
+	t.Current.Duration += snooze
+	t.Current.EndTime = now.Add(snooze)
+	t.Current.Timeline = append(t.Current.Timeline, Timeline{
+		StartTime: now,
+		EndTime:   t.Current.EndTime,
+	})
+
+	t.clock = btimer.New(snooze)
+
+	t.announce("Snoozed for %s.", spokenDuration(snooze))
+
+	return t.clock.Init()
+}
+
+// limitReached reports whether the maximum number of work sessions for this
+// run of the timer or for the day has been completed.
+func (t *Timer) limitReached() bool {
+	if t.Opts.CLI.MaxSessions > 0 && t.completed >= t.Opts.CLI.MaxSessions {
+		return true
+	}
+
+	perDay := t.Opts.Settings.MaxSessionsPerDay
+
+	return perDay > 0 && t.today.Completed >= perDay
+}
+
+// shutdown stops the timer instead of starting another session and shows a
+// summary of the work done today along with the shutdown checklist.
+func (t *Timer) shutdown() tea.Cmd {
+	t.settings = shutdownView
+
+	removeStatusFile()
+
+	if t.accessible() {
+		t.announceShutdown()
+
+		return nil
+	}
+
+	checklist := t.Opts.Settings.ShutdownChecklist
+	if len(checklist) == 0 {
+		return tea.ExitAltScreen
+	}
+
+	t.shutdownForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Key("checklist").
+				Title("Shutdown checklist").
+				Options(huh.NewOptions(checklist...)...),
+		),
+	)
+
+	return tea.Batch(tea.ExitAltScreen, t.shutdownForm.Init())
+}
+
+// promptNotes asks what was achieved in the work session that just ended. The
+// next session starts once the prompt is submitted or dismissed.
+func (t *Timer) promptNotes() tea.Cmd {
+	if t.accessible() {
+		return t.askNotes()
+	}
+
+	t.notesForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Key("notes").
+				Title("What did you get done?").
+				Description("Press ctrl+c to skip"),
+		),
+	)
+	t.settings = notesView
+
+	return t.notesForm.Init()
+}
+
+// logInterruption records an interruption in the current work session without
+// pausing the timer, and prompts for an optional reason.
+func (t *Timer) logInterruption(kind models.InterruptionKind) tea.Cmd {
+	t.Current.Interruptions = append(t.Current.Interruptions, models.Interruption{
+		Time: time.Now(),
+		Kind: kind,
+	})
+
+	if t.accessible() {
+		return t.askInterruptionReason(kind)
+	}
+
+	t.interruptionForm = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("reason").
+				Title(fmt.Sprintf("Logged %s interruption", kind)).
+				Placeholder("Reason (optional)").
+				CharLimit(maxReasonLength),
+		),
+	)
+	t.settings = interruptionView
+
+	return t.interruptionForm.Init()
+}
+
+// closeInterruptionForm saves the reason for the last interruption if it was
+// submitted and dismisses the prompt.
+func (t *Timer) closeInterruptionForm() {
+	if t.interruptionForm.State == huh.StateCompleted {
+		last := len(t.Current.Interruptions) - 1
+		t.Current.Interruptions[last].Reason = strings.TrimSpace(
+			t.interruptionForm.GetString("reason"),
+		)
+	}
+
+	t.interruptionForm = nil
+	t.settings = ""
+}
+
+// saveNotes attaches the submitted notes to the work session that just ended.
+func (t *Timer) saveNotes() error {
+	if t.notesForm.State != huh.StateCompleted {
+		return nil
+	}
+
+	t.Current.Notes = strings.TrimSpace(t.notesForm.GetString("notes"))
+	if t.Current.Notes == "" {
+		return nil
+	}
+
+	return t.persist()
+}
+
 func (t *Timer) postSession() error {
 	// t.notify(t.Context, t.Current.Name, sessName)
 	err := t.runSessionCmd(t.Opts.Settings.Cmd)
@@ -302,7 +831,7 @@ func (t *Timer) postSession() error {
 		return err
 	}
 
-	return nil
+	return t.updateSourceTask()
 }
 
 // persist saves the current timer and session to the database.
@@ -313,24 +842,163 @@ func (t *Timer) persist() error {
 		return nil
 	}
 
-	sess.UpdateEndTime(t.clock.Timedout())
+	sess.UpdateEndTime(t.timedout())
+
+	// the length of an unfinished flowtime session is the time worked so far,
+	// and a snoozed session was already completed before it was reopened
+	if (t.isFlow() || t.snoozed) && !sess.Completed {
+		sess.Duration = sess.Elapsed()
+		sess.Completed = t.snoozed
+	}
 
 	sess.Normalise()
 
-	sessModel := sess.ToDBModel()
+	if t.unsaved == nil {
+		t.unsaved = make(map[time.Time]*models.Session)
+	}
+
+	t.unsaved[sess.StartTime] = sess.ToDBModel()
+
+	return t.save()
+}
 
-	m := map[time.Time]*models.Session{
-		sess.StartTime: sessModel,
+// save writes the sessions that haven't been saved yet to the database. If
+// the database is locked by another Focus command, the sessions are kept so
+// that saving can be retried, and the error is shown until it succeeds.
+func (t *Timer) save() error {
+	if len(t.unsaved) == 0 {
+		return nil
 	}
 
-	err := t.db.UpdateSessions(m)
+	err := store.Use(t.db, func() error {
+		return t.db.UpdateSessions(t.unsaved)
+	})
 	if err != nil {
+		if t.saveErr == nil {
+			t.announce("The session could not be saved: %v. Retrying.", err)
+		}
+
+		t.saveErr = err
+
 		return err
 	}
 
+	if t.saveErr != nil {
+		t.announce("The session was saved.")
+	}
+
+	clear(t.unsaved)
+	t.saveErr = nil
+
+	t.updateToday()
+
 	return nil
 }
 
+// retrySave schedules another attempt at saving the sessions that couldn't be
+// saved, unless one is already scheduled.
+func (t *Timer) retrySave() tea.Cmd {
+	if len(t.unsaved) == 0 || t.retryPending {
+		return nil
+	}
+
+	t.retryPending = true
+
+	return tea.Tick(saveRetryInterval, func(time.Time) tea.Msg {
+		return saveRetryMsg{}
+	})
+}
+
+// Flush makes a last attempt at saving the sessions that couldn't be saved
+// while the timer was running. It must be called once the timer exits so that
+// a session is never lost without a warning.
+func (t *Timer) Flush() error {
+	for range flushAttempts {
+		if t.save() == nil {
+			return nil
+		}
+	}
+
+	return errSessionsNotSaved.Fmt(len(t.unsaved), t.saveErr)
+}
+
+// Close releases the lock that marks the timer as running so that another
+// timer can be started. It is called once the timer has exited and its
+// sessions have been flushed.
+func (t *Timer) Close() error {
+	if t.lock == nil {
+		return nil
+	}
+
+	err := t.lock.Release()
+	t.lock = nil
+
+	return err
+}
+
+// updateGoals recomputes the progress towards the configured goals from the
+// sessions saved in the current week.
+func (t *Timer) updateGoals() {
+	if t.Opts.Goals.Daily == 0 && t.Opts.Goals.Weekly == 0 {
+		return
+	}
+
+	now := time.Now()
+
+	_ = store.Use(t.db, func() error {
+		sessions, err := t.db.GetSessions(stats.StartOfWeek(now), now, nil)
+		if err != nil {
+			return err
+		}
+
+		t.goals = stats.ComputeGoals(sessions, t.Opts.Goals, now)
+
+		return nil
+	})
+}
+
+// updateToday summarises the sessions saved today. The summary is only needed
+// when the number of work sessions or the time available for them is limited,
+// when the today panel is shown, or when the summary can be read out in
+// accessible mode.
+func (t *Timer) updateToday() {
+	if t.Opts.CLI.MaxSessions == 0 && t.Opts.Settings.MaxSessionsPerDay == 0 &&
+		t.Opts.CLI.Until.IsZero() && !t.showToday && !t.accessible() {
+		return
+	}
+
+	now := time.Now()
+
+	_ = store.Use(t.db, func() error {
+		sessions, err := t.db.GetSessions(timeutil.RoundToStart(now), now, nil)
+		if err != nil {
+			return err
+		}
+
+		t.today = stats.SummariseDay(sessions, now)
+
+		return nil
+	})
+}
+
+// updateTask recounts the pomodoros completed for the active task.
+func (t *Timer) updateTask() {
+	if t.task == nil {
+		return
+	}
+
+	_ = store.Use(t.db, func() error {
+		sessions, err := t.db.GetSessions(t.task.CreatedAt, time.Now(), nil)
+		if err != nil {
+			return err
+		}
+
+		t.taskPomodoros = stats.TaskPomodoros(sessions)[t.task.ID]
+
+		return nil
+	})
+}
+
 // writeStatusFile writes the current timer status to a JSON file.
 // The status includes session details, work cycle count, and timing information.
 // This file is used by other processes to query the timer's current state.
@@ -343,6 +1011,17 @@ func (t *Timer) writeStatusFile() error {
 		Tags:              sess.Tags,
 		LongBreakInterval: t.Opts.Settings.LongBreakInterval,
 		EndTime:           sess.EndTime,
+		DailyGoal:         t.goals.Daily.String(),
+	}
+
+	if len(t.Opts.CLI.Sequence) > 0 {
+		s.WorkCycle = t.step + 1
+		s.LongBreakInterval = len(t.Opts.CLI.Sequence)
+	}
+
+	if t.isFlow() {
+		s.Flow = true
+		s.Elapsed = int(t.flowElapsed().Seconds())
 	}
 
 	statusFilePath := config.StatusFilePath()
@@ -455,24 +1134,20 @@ func (t *Timer) notify(
 	speaker.Close()
 }
 
+// removeStatusFile deletes the status file so that the timer is no longer
+// reported as running.
+func removeStatusFile() {
+	_ = os.Remove(config.StatusFilePath())
+}
+
 // ReportStatus reports the status of the currently running timer.
 func (t *Timer) ReportStatus() error {
-	dbFilePath := config.DBFilePath()
-	statusFilePath := config.StatusFilePath()
-
-	var fileMode fs.FileMode = 0o600
-
-	_, err := bolt.Open(dbFilePath, fileMode, &bolt.Options{
-		Timeout: 100 * time.Millisecond,
-	})
-	// This means focus is not running, so no status to report
-	if err == nil {
+	// the status file is left behind if the timer did not exit cleanly
+	if !config.TimerRunning() {
 		return nil
 	}
 
-	if !errors.Is(err, bolterr.ErrTimeout) {
-		return err
-	}
+	statusFilePath := config.StatusFilePath()
 
 	fileBytes, err := os.ReadFile(statusFilePath)
 	if err != nil {
@@ -487,6 +1162,20 @@ func (t *Timer) ReportStatus() error {
 		return err
 	}
 
+	if s.Flow {
+		m, sec := timeutil.SecsToMinsAndSecs(float64(s.Elapsed))
+
+		text := fmt.Sprintf("[Flow]: %02d:%02d elapsed", m, sec)
+
+		if s.DailyGoal != "" {
+			text += " · " + s.DailyGoal + " today"
+		}
+
+		pterm.Println(text)
+
+		return nil
+	}
+
 	sess := &Session{
 		EndTime: s.EndTime,
 	}
@@ -510,6 +1199,12 @@ func (t *Timer) ReportStatus() error {
 		text = "[Long break]"
 	}
 
+	if s.DailyGoal != "" {
+		pterm.Printfln("%s: %02d:%02d · %s today", text, tr.M, tr.S, s.DailyGoal)
+
+		return nil
+	}
+
 	pterm.Printfln("%s: %02d:%02d", text, tr.M, tr.S)
 
 	return nil
@@ -0,0 +1,46 @@
+package timer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/config"
+)
+
+// captureOutput collects what the timer prints in accessible mode.
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	stdout := config.Stdout
+	config.Stdout = &buf
+
+	t.Cleanup(func() {
+		config.Stdout = stdout
+	})
+
+	return &buf
+}
+
+func TestAccessibleHelpWhileScheduled(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.CLI.Accessible = true
+	cfg.CLI.At = time.Now().Add(time.Hour)
+
+	tm, _ := newTimer(t, cfg)
+
+	out := captureOutput(t)
+
+	err := tm.RunAccessible(strings.NewReader("?\nq\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, out.String(), "The first session starts at")
+	assert.Contains(t, out.String(), "Commands: q quit, ? help.")
+}
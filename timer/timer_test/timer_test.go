@@ -0,0 +1,184 @@
+package timer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	btimer "github.com/charmbracelet/bubbles/timer"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
+	"github.com/ayoisaiah/focus/internal/tagquery"
+	"github.com/ayoisaiah/focus/timer"
+)
+
+// memDB is an in-memory implementation of store.DB.
+type memDB struct {
+	sessions map[time.Time]*models.Session
+}
+
+func (db *memDB) GetSessions(
+	time.Time,
+	time.Time,
+	*tagquery.Query,
+) ([]*models.Session, error) {
+	return nil, nil
+}
+
+func (db *memDB) UpdateSessions(sessions map[time.Time]*models.Session) error {
+	for k, v := range sessions {
+		db.sessions[k] = v
+	}
+
+	return nil
+}
+
+func (db *memDB) DeleteSessions([]time.Time) error {
+	return nil
+}
+
+func (db *memDB) AddTask(*models.Task) error {
+	return nil
+}
+
+func (db *memDB) GetTask(int) (*models.Task, error) {
+	return nil, nil //nolint:nilnil // tasks are not used by the timer tests
+}
+
+func (db *memDB) GetTasks() ([]*models.Task, error) {
+	return nil, nil
+}
+
+func (db *memDB) UpdateTask(*models.Task) error {
+	return nil
+}
+
+func (db *memDB) Open() error {
+	return nil
+}
+
+func (db *memDB) Close() error {
+	return nil
+}
+
+// newConfig returns a config that waits for a key press before each session.
+// Settings are added to the settings section of the config file. The lock and
+// status files are kept in a temporary directory.
+func newConfig(t *testing.T, settings ...string) *config.Config {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	config.SetDataDir(dir)
+
+	configPath := filepath.Join(dir, "config.yml")
+
+	yml := "settings:\n  auto_start_break: false\n  auto_start_work: false\n"
+
+	for _, setting := range settings {
+		yml += "  " + setting + "\n"
+	}
+
+	err := os.WriteFile(configPath, []byte(yml), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.New(config.WithViperConfig(configPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cfg
+}
+
+// newTimer returns a timer for cfg that saves its sessions in memory. The
+// timer is closed when the test ends.
+func newTimer(t *testing.T, cfg *config.Config) (*timer.Timer, *memDB) {
+	t.Helper()
+
+	db := &memDB{sessions: make(map[time.Time]*models.Session)}
+
+	tm, err := timer.New(db, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		_ = tm.Close()
+	})
+
+	return tm, db
+}
+
+var (
+	timeout = btimer.TimeoutMsg{}
+	enter   = tea.KeyMsg{Type: tea.KeyEnter}
+	skip    = tea.KeyMsg{Type: tea.KeyCtrlC}
+	snooze  = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")}
+)
+
+const notesPrompt = "What did you get done?"
+
+func TestSnoozeBreak(t *testing.T) {
+	tm, db := newTimer(t, newConfig(t))
+	tm.Init()
+
+	// complete the first work session and its break
+	tm.Update(timeout)
+	tm.Update(enter)
+
+	assert.Equal(t, config.ShortBreak, tm.Current.Name)
+
+	tm.Update(timeout)
+
+	assert.Equal(t, 2, tm.WorkCycle)
+
+	tm.Update(snooze)
+
+	assert.Equal(t, config.ShortBreak, tm.Current.Name, "the break is reopened")
+
+	tm.Update(timeout)
+
+	assert.Equal(t, 2, tm.WorkCycle, "a snoozed break ends the same cycle")
+	assert.Len(t, db.sessions, 1)
+
+	tm.Update(enter)
+
+	assert.Equal(t, config.Work, tm.Current.Name)
+	assert.Equal(t, 2, tm.WorkCycle)
+}
+
+func TestSnoozeWork(t *testing.T) {
+	tm, db := newTimer(t, newConfig(t, "notes_prompt: true"))
+	tm.Init()
+
+	tm.Update(timeout)
+
+	assert.Contains(t, tm.View(), notesPrompt)
+
+	tm.Update(skip)
+	tm.Update(snooze)
+
+	assert.Equal(t, config.Work, tm.Current.Name, "the work session is reopened")
+
+	tm.Update(timeout)
+
+	assert.NotContains(t, tm.View(), notesPrompt, "notes are only asked for once")
+	assert.Equal(t, 1, tm.WorkCycle)
+
+	if assert.Len(t, db.sessions, 1) {
+		for _, sess := range db.sessions {
+			assert.True(t, sess.Completed)
+		}
+	}
+
+	tm.Update(enter)
+
+	assert.Equal(t, config.ShortBreak, tm.Current.Name)
+	assert.Equal(t, 1, tm.WorkCycle)
+}
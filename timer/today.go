@@ -0,0 +1,115 @@
+package timer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ayoisaiah/focus/stats"
+)
+
+// sparkBlocks are the bars of the hourly sparkline from lowest to highest.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+const (
+	// maxTodaySessions is the number of sessions listed in the today panel.
+	// Only the most recent ones are shown if there are more.
+	maxTodaySessions = 10
+
+	// todayPanelWidth is the width of the today panel including its margin.
+	// Longer lines are cut off.
+	todayPanelWidth = 36
+)
+
+// sparkline draws a bar for each hour of the day whose height is proportional
+// to the time focused in that hour.
+func sparkline(hourly [24]time.Duration) string {
+	var highest time.Duration
+
+	for _, d := range hourly {
+		highest = max(highest, d)
+	}
+
+	var s strings.Builder
+
+	for _, d := range hourly {
+		level := 0
+		if highest > 0 && d > 0 {
+			level = 1 + int(float64(d)/float64(highest)*float64(len(sparkBlocks)-2))
+		}
+
+		s.WriteRune(sparkBlocks[level])
+	}
+
+	return s.String()
+}
+
+// todayView lists the sessions saved today along with the total focus time,
+// the number of completed sessions, and the focus time in each hour.
+func (t *Timer) todayView() string {
+	var s strings.Builder
+
+	s.WriteString(defaultStyle.accent.Render("Today"))
+	s.WriteString("\n\n")
+
+	sessions := t.today.Sessions
+	if len(sessions) > maxTodaySessions {
+		s.WriteString(
+			defaultStyle.muted.Render(
+				fmt.Sprintf("+%d earlier", len(sessions)-maxTodaySessions),
+			) + "\n",
+		)
+
+		sessions = sessions[len(sessions)-maxTodaySessions:]
+	}
+
+	if len(sessions) == 0 {
+		s.WriteString(defaultStyle.muted.Render("No sessions yet") + "\n")
+	}
+
+	timeFormat := strings.Replace(t.timeFormat(), ":05", "", 1)
+
+	for _, sess := range sessions {
+		status := defaultStyle.success.Render("✔")
+		if !sess.Completed {
+			status = defaultStyle.error.Render("✘")
+		}
+
+		tags := strings.Join(sess.Tags, ", ")
+		if tags == "" {
+			tags = defaultStyle.muted.Render("untagged")
+		}
+
+		s.WriteString(
+			fmt.Sprintf(
+				"%s %s %s\n",
+				status,
+				sess.StartTime.Format(timeFormat),
+				tags,
+			),
+		)
+	}
+
+	s.WriteString(
+		fmt.Sprintf(
+			"\n%s · %d completed\n\n",
+			stats.FormatDuration(t.today.Total),
+			t.today.Completed,
+		),
+	)
+	s.WriteString(sparkline(t.today.Hourly) + "\n")
+	s.WriteString(defaultStyle.muted.Render(fmt.Sprintf("%-12s%12s", "00", "23")))
+
+	return defaultStyle.panel.Render(s.String())
+}
+
+// withToday places the today panel to the right of view if it is shown.
+func (t *Timer) withToday(view string) string {
+	if !t.showToday {
+		return view
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, view, t.todayView())
+}
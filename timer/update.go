@@ -6,6 +6,7 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/stopwatch"
 	btimer "github.com/charmbracelet/bubbles/timer"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
@@ -13,6 +14,7 @@ import (
 	"github.com/gopxl/beep/v2/speaker"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/models"
 )
 
 // handleTimerTick processes timer tick events.
@@ -22,6 +24,8 @@ func (t *Timer) handleTimerTick(msg btimer.TickMsg) (tea.Model, tea.Cmd) {
 
 	_ = t.writeStatusFile()
 
+	t.announceProgress()
+
 	return t, cmd
 }
 
@@ -50,6 +54,48 @@ func (t *Timer) handleTimerStartStop(
 	return t, cmd
 }
 
+// handleStopwatchTick processes the ticks of the stopwatch used by flowtime
+// work sessions.
+func (t *Timer) handleStopwatchTick(msg stopwatch.TickMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	t.stopwatch, cmd = t.stopwatch.Update(msg)
+
+	_ = t.writeStatusFile()
+
+	t.announceProgress()
+
+	return t, cmd
+}
+
+// handleStopwatchStartStop pauses and resumes flowtime work sessions.
+func (t *Timer) handleStopwatchStartStop(
+	msg stopwatch.StartStopMsg,
+) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	t.stopwatch, cmd = t.stopwatch.Update(msg)
+
+	if t.Current.Completed {
+		return t, cmd
+	}
+
+	if t.stopwatch.Running() {
+		t.StartTime = time.Now()
+		t.Current.Resume()
+	} else {
+		_ = t.persist()
+	}
+
+	if t.SoundStream != nil {
+		if !t.stopwatch.Running() {
+			_ = speaker.Suspend()
+		} else {
+			_ = speaker.Resume()
+		}
+	}
+
+	return t, cmd
+}
+
 func (t *Timer) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
@@ -64,27 +110,260 @@ func (t *Timer) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return t, cmd
 }
 
+// handleNotes passes messages to the notes prompt shown after a work session
+// and starts the next session once the prompt is submitted or dismissed.
+func (t *Timer) handleNotes(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := t.notesForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		t.notesForm = f
+	}
+
+	if t.notesForm.State == huh.StateNormal {
+		return t, cmd
+	}
+
+	_ = t.saveNotes()
+
+	t.notesForm = nil
+	t.settings = ""
+
+	_ = t.postSession()
+
+	return t, t.initSession()
+}
+
+// handleInterruption passes messages to the interruption reason prompt while
+// the timer keeps running.
+func (t *Timer) handleInterruption(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := t.interruptionForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		t.interruptionForm = f
+	}
+
+	if t.interruptionForm.State != huh.StateNormal {
+		t.closeInterruptionForm()
+	}
+
+	return t, cmd
+}
+
+// handleTags passes messages to the tags prompt while the timer keeps running.
+func (t *Timer) handleTags(msg tea.Msg) (tea.Model, tea.Cmd) {
+	form, cmd := t.tagsForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		t.tagsForm = f
+	}
+
+	if t.tagsForm.State != huh.StateNormal {
+		t.closeTagsForm()
+	}
+
+	return t, cmd
+}
+
+// handleSchedule counts down to the start of the first session when it is
+// scheduled with --at.
+func (t *Timer) handleSchedule(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case btimer.TimeoutMsg:
+		t.Opts.CLI.At = time.Time{}
+		t.Opts.CLI.StartTime = time.Now()
+
+		return t, t.Init()
+
+	case btimer.TickMsg, btimer.StartStopMsg:
+		t.clock, cmd = t.clock.Update(msg)
+
+		return t, cmd
+
+	case tea.KeyMsg:
+		if key.Matches(msg, defaultKeymap.quit) {
+			return t, tea.Batch(tea.ClearScreen, tea.Quit)
+		}
+
+	case tea.WindowSizeMsg:
+		t.resize(msg)
+	}
+
+	return t, nil
+}
+
+// resize fits the progress bar to the width of the terminal, leaving room for
+// the today panel if it is shown, and records the terminal size for the
+// full-screen display mode.
+func (t *Timer) resize(msg tea.WindowSizeMsg) {
+	t.width, t.height = msg.Width, msg.Height
+
+	t.progress.Width = msg.Width - padding*2 - 4
+	if t.showToday {
+		t.progress.Width -= todayPanelWidth
+	}
+
+	if t.progress.Width > maxWidth {
+		t.progress.Width = maxWidth
+	}
+}
+
+// handleShutdown passes messages to the shutdown checklist and exits once the
+// checklist is submitted or dismissed.
+func (t *Timer) handleShutdown(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if t.shutdownForm == nil {
+		if msg, ok := msg.(tea.KeyMsg); ok &&
+			(key.Matches(msg, defaultKeymap.quit) ||
+				key.Matches(msg, defaultKeymap.enter)) {
+			return t, tea.Quit
+		}
+
+		return t, nil
+	}
+
+	form, cmd := t.shutdownForm.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		t.shutdownForm = f
+	}
+
+	if t.shutdownForm.State == huh.StateNormal {
+		return t, cmd
+	}
+
+	return t, tea.Quit
+}
+
+// Update handles a message and schedules another attempt at saving the
+// sessions that couldn't be saved, whatever state the timer is in.
 func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(saveRetryMsg); ok {
+		t.retryPending = false
+
+		_ = t.save()
+
+		return t, t.retrySave()
+	}
+
+	model, cmd := t.update(msg)
+
+	return model, tea.Batch(cmd, t.retrySave())
+}
+
+func (t *Timer) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if msg, ok := msg.(commandMsg); ok {
+		return t.handleCommand(string(msg))
+	}
+
+	if t.scheduled() {
+		return t.handleSchedule(msg)
+	}
+
+	if t.settings == shutdownView {
+		return t.handleShutdown(msg)
+	}
+
+	if t.settings == notesView {
+		return t.handleNotes(msg)
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && t.settings == interruptionView {
+		return t.handleInterruption(msg)
+	}
+
+	if _, ok := msg.(tea.KeyMsg); ok && t.settings == tagsView {
+		return t.handleTags(msg)
+	}
+
 	switch msg := msg.(type) {
 	case btimer.TickMsg:
+		if t.isFlow() {
+			return t, nil
+		}
+
 		return t.handleTimerTick(msg)
 
 	case btimer.StartStopMsg:
+		if t.isFlow() {
+			return t, nil
+		}
+
 		return t.handleTimerStartStop(msg)
 
 	case btimer.TimeoutMsg:
-		_ = t.persist()
+		if t.isFlow() {
+			return t, nil
+		}
 
-		_ = t.postSession()
+		return t, t.completeSession()
 
-		cmd = t.initSession()
+	case stopwatch.TickMsg:
+		if !t.isFlow() {
+			return t, nil
+		}
 
-		return t, cmd
+		return t.handleStopwatchTick(msg)
+
+	case stopwatch.StartStopMsg:
+		if !t.isFlow() {
+			return t, nil
+		}
+
+		return t.handleStopwatchStartStop(msg)
 
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, defaultKeymap.finish) &&
+			t.isFlow() &&
+			!t.waitForNextSession:
+			if t.settings != "" {
+				break
+			}
+
+			return t, t.finishFlow()
+
+		case key.Matches(msg, defaultKeymap.snooze):
+			if t.settings != "" || !t.waitForNextSession {
+				break
+			}
+
+			// a flowtime work session has no set length to snooze
+			if t.Opts.CLI.Flow && t.Current.Name == config.Work {
+				return t, nil
+			}
+
+			return t, t.snooze()
+
+		case key.Matches(msg, defaultKeymap.extend):
+			if t.settings != "" {
+				break
+			}
+
+			if t.Current.Name != config.Work || t.isFlow() || t.timedout() {
+				return t, nil
+			}
+
+			t.extend()
+
+			t.announce(
+				"Extended by %s, %s.",
+				spokenDuration(t.Opts.Settings.Extend),
+				t.progressText(),
+			)
+
+			return t, nil
+
+		case key.Matches(msg, defaultKeymap.finishEarly):
+			if t.settings != "" {
+				break
+			}
+
+			if t.Current.Name != config.Work || t.isFlow() || t.timedout() ||
+				t.waitForNextSession {
+				return t, nil
+			}
+
+			return t, t.finishEarly()
+
 		case key.Matches(msg, defaultKeymap.enter):
 			if t.settings != "" {
 				break
@@ -93,17 +372,17 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if t.waitForNextSession {
 				t.waitForNextSession = false
 
-				sessName := t.nextSession(t.Current.Name)
-				t.Current = t.newSession(sessName)
-
-				t.clock = btimer.New(t.Current.Duration)
-				cmd = t.clock.Init()
+				cmd = t.startSession(t.nextSession(t.Current.Name))
 			}
 
 			return t, cmd
 
 		case key.Matches(msg, defaultKeymap.sound):
-			if !t.clock.Timedout() {
+			if t.accessible() && !t.timedout() {
+				return t, t.askSound()
+			}
+
+			if !t.timedout() {
 				t.soundForm = huh.NewForm(
 					huh.NewGroup(
 						huh.NewSelect[string]().
@@ -119,9 +398,33 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return t, nil
 
+		case key.Matches(msg, defaultKeymap.internalInterrupt):
+			if t.settings != "" {
+				break
+			}
+
+			if t.Current.Name != config.Work || !t.running() {
+				return t, nil
+			}
+
+			return t, t.logInterruption(models.InternalInterruption)
+
+		case key.Matches(msg, defaultKeymap.externalInterrupt):
+			if t.settings != "" {
+				break
+			}
+
+			if t.Current.Name != config.Work || !t.running() {
+				return t, nil
+			}
+
+			return t, t.logInterruption(models.ExternalInterruption)
+
 		case key.Matches(msg, defaultKeymap.esc):
 			// Skip break sessions
 			if t.Current.Name != config.Work && t.clock.Running() {
+				t.announce("%s skipped.", t.Current.Name)
+
 				return t, tea.Batch(t.clock.Stop(), t.initSession())
 			}
 
@@ -129,6 +432,39 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return t, nil
 
+		case key.Matches(msg, defaultKeymap.display):
+			if t.settings != "" || t.waitForNextSession || t.accessible() {
+				break
+			}
+
+			return t, t.toggleDisplay()
+
+		case key.Matches(msg, defaultKeymap.tags):
+			if t.settings != "" || t.waitForNextSession {
+				break
+			}
+
+			return t, t.promptTags()
+
+		case key.Matches(msg, defaultKeymap.today):
+			if t.settings != "" || t.waitForNextSession {
+				break
+			}
+
+			// the summary is read out instead of shown in a panel
+			if t.accessible() {
+				t.updateToday()
+				t.announceToday()
+
+				return t, nil
+			}
+
+			t.showToday = !t.showToday
+			t.updateToday()
+			t.resize(tea.WindowSizeMsg{Width: t.width, Height: t.height})
+
+			return t, nil
+
 		case key.Matches(msg, defaultKeymap.togglePlay):
 			if t.Current.Name != config.Work {
 				return t, nil
@@ -136,6 +472,16 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// TODO: Check strict mode
 
+			if t.running() {
+				t.announce("Paused, %s.", t.progressText())
+			} else {
+				t.announce("Resumed, %s.", t.progressText())
+			}
+
+			if t.isFlow() {
+				return t, t.stopwatch.Toggle()
+			}
+
 			cmd = t.clock.Toggle()
 
 			return t, cmd
@@ -143,16 +489,17 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, defaultKeymap.quit):
 			_ = t.persist()
 
+			removeStatusFile()
+
+			t.announce("Timer stopped.")
+
 			return t, tea.Batch(tea.ClearScreen, tea.Quit)
 		}
 
 		// return t.handleKeyPress(msg)
 
 	case tea.WindowSizeMsg:
-		t.progress.Width = msg.Width - padding*2 - 4
-		if t.progress.Width > maxWidth {
-			t.progress.Width = maxWidth
-		}
+		t.resize(msg)
 
 		return t, nil
 
@@ -166,6 +513,14 @@ func (t *Timer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return t, cmd
 	}
 
+	if t.settings == interruptionView {
+		return t.handleInterruption(msg)
+	}
+
+	if t.settings == tagsView {
+		return t.handleTags(msg)
+	}
+
 	if t.soundForm != nil {
 		slog.Info(spew.Sdump(msg))
 
@@ -6,10 +6,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
 
 	"github.com/ayoisaiah/focus/internal/config"
+	"github.com/ayoisaiah/focus/internal/tagquery"
 	"github.com/ayoisaiah/focus/internal/timeutil"
+	"github.com/ayoisaiah/focus/stats"
 )
 
 // formatTimeRemaining returns the remaining time formatted as "MM:SS".
@@ -22,6 +23,44 @@ func (t *Timer) formatTimeRemaining() string {
 	)
 }
 
+// formatTimeElapsed returns the time worked in a flowtime session formatted as
+// "MM:SS".
+func (t *Timer) formatTimeElapsed() string {
+	m, s := timeutil.SecsToMinsAndSecs(t.flowElapsed().Seconds())
+
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// timeFormat returns the layout used to display times of day.
+func (t *Timer) timeFormat() string {
+	if t.Opts.Settings.TwentyFourHour {
+		return "15:04:05"
+	}
+
+	return "03:04:05 PM"
+}
+
+// scheduledView counts down to the start of the first session when it is
+// scheduled with --at.
+func (t *Timer) scheduledView() string {
+	var s strings.Builder
+
+	s.WriteString(defaultStyle.work.Render())
+	s.WriteString(
+		strings.TrimSpace(
+			defaultStyle.help.SetString(
+				"starts at " + t.Opts.CLI.At.Format(t.timeFormat()),
+			).String()))
+	s.WriteString("\n\n")
+	s.WriteString(t.formatTimeRemaining())
+	s.WriteString("\n")
+	s.WriteString("\n" + t.help.ShortHelpView([]key.Binding{
+		defaultKeymap.quit,
+	}))
+
+	return s.String()
+}
+
 func (t *Timer) sessionPromptView() string {
 	var s strings.Builder
 
@@ -34,17 +73,29 @@ func (t *Timer) sessionPromptView() string {
 	}
 
 	s.WriteString(
-		lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#DB2763")).
-			SetString(title).
-			String(),
+		defaultStyle.accent.Render(title),
 	)
 	s.WriteString("\n\n" + msg)
 
 	return s.String()
 }
 
+// timerView renders the running session in the selected display mode.
 func (t *Timer) timerView() string {
+	switch t.display {
+	case config.DisplayFullscreen:
+		return t.fullscreenView()
+	case config.DisplayCompact:
+		return t.compactView()
+	case config.DisplayMinimal:
+		return t.minimalView()
+	}
+
+	return defaultStyle.base.Render(t.withToday(t.standardView()))
+}
+
+// standardView renders the session message, state, time, and progress bar.
+func (t *Timer) standardView() string {
 	var s strings.Builder
 
 	percent := (float64(
@@ -55,28 +106,18 @@ func (t *Timer) timerView() string {
 
 	timeRemaining := t.formatTimeRemaining()
 
-	switch t.Current.Name {
-	case config.Work:
-		s.WriteString(defaultStyle.work.Render())
-	case config.ShortBreak:
-		s.WriteString(defaultStyle.shortBreak.Render())
-	case config.LongBreak:
-		s.WriteString(defaultStyle.longBreak.Render())
-	}
+	s.WriteString(t.sessionStyle().Render())
 
-	var timeFormat string
-	if t.Opts.Settings.TwentyFourHour {
-		timeFormat = "15:04:05"
-	} else {
-		timeFormat = "03:04:05 PM"
-	}
+	timeFormat := t.timeFormat()
 
-	if !t.clock.Running() && !t.clock.Timedout() {
+	if t.paused() {
+		s.WriteString(
+			defaultStyle.accent.Render("[Paused]"),
+		)
+	} else if t.isFlow() {
 		s.WriteString(
-			lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#DB2763")).
-				SetString("[Paused]").
-				String(),
+			strings.TrimSpace(
+				defaultStyle.help.SetString("since " + t.Current.StartTime.Format(timeFormat)).String()),
 		)
 	} else {
 		s.WriteString(
@@ -85,19 +126,71 @@ func (t *Timer) timerView() string {
 		)
 	}
 
-	if t.Current.Name == config.Work {
+	if position := t.positionText(); position != "" {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					" (" + position + ")",
+				).String()))
+	}
+
+	if until := t.Opts.CLI.Until; !until.IsZero() {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					" · block ends " + until.Format(timeFormat),
+				).String()))
+	}
+
+	if n := len(t.Current.Interruptions); n > 0 {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					fmt.Sprintf(" · %d interrupted", n),
+				).String()))
+	}
+
+	if t.goals.Daily != nil {
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					" · " + t.goals.Daily.String() + " today",
+				).String()))
+	}
+
+	if t.task != nil {
+		s.WriteString("\n")
 		s.WriteString(
 			strings.TrimSpace(
 				defaultStyle.help.SetString(
 					fmt.Sprintf(
-						" (%d/%d)",
-						t.WorkCycle,
-						t.Opts.Settings.LongBreakInterval,
+						"▸ %s (%d/%d pomodoros)",
+						t.task.Title,
+						t.taskPomodoros,
+						t.task.Estimate,
 					),
 				).String()))
 	}
 
+	if t.sourceTask != nil {
+		s.WriteString("\n")
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					"▸ " + t.sourceTask.Description,
+				).String()))
+	}
+
 	s.WriteString("\n\n")
+
+	if t.isFlow() {
+		s.WriteString(t.formatTimeElapsed())
+		s.WriteString("\n")
+		s.WriteString(t.helpView())
+
+		return s.String()
+	}
+
 	s.WriteString(timeRemaining)
 	s.WriteString("\n\n")
 	s.WriteString(t.progress.ViewAs(float64(1 - percent)))
@@ -107,6 +200,69 @@ func (t *Timer) timerView() string {
 	return s.String()
 }
 
+// summaryMessage explains why the timer stopped for the day.
+func (t *Timer) summaryMessage() string {
+	if t.untilReached() {
+		return fmt.Sprintf(
+			"Your focus block ends at %s.",
+			t.Opts.CLI.Until.Format(t.timeFormat()),
+		)
+	}
+
+	if t.Opts.CLI.MaxSessions > 0 && t.completed >= t.Opts.CLI.MaxSessions {
+		return fmt.Sprintf("You've completed %d work sessions.", t.completed)
+	}
+
+	return fmt.Sprintf(
+		"You've reached your limit of %d work sessions for today.",
+		t.Opts.Settings.MaxSessionsPerDay,
+	)
+}
+
+// summaryView shows the work done today once the timer stops for the day.
+func (t *Timer) summaryView() string {
+	var s strings.Builder
+
+	msg := t.summaryMessage()
+
+	s.WriteString(
+		defaultStyle.accent.Render("That's a wrap for today"),
+	)
+	s.WriteString("\n\n" + msg + "\n\n")
+	s.WriteString(
+		fmt.Sprintf(
+			"Today: %s · %d sessions completed",
+			stats.FormatDuration(t.today.Total),
+			t.today.Completed,
+		),
+	)
+
+	for _, tag := range t.today.Tags {
+		depth := len(tagquery.Lineage(tag.Name))
+
+		s.WriteString("\n" + strings.Repeat("  ", depth) + tag.Name + " ")
+		s.WriteString(
+			strings.TrimSpace(
+				defaultStyle.help.SetString(
+					stats.FormatDuration(tag.Duration),
+				).String()))
+	}
+
+	if t.shutdownForm != nil {
+		if t.shutdownForm.State == huh.StateNormal {
+			s.WriteString("\n\n" + t.shutdownForm.View())
+		}
+
+		return s.String()
+	}
+
+	s.WriteString("\n" + t.help.ShortHelpView([]key.Binding{
+		defaultKeymap.quit,
+	}))
+
+	return s.String()
+}
+
 func (t *Timer) pickSoundView() string {
 	if t.soundForm.State == huh.StateCompleted {
 		sound := t.soundForm.GetString("sound")
@@ -125,36 +281,116 @@ func (t *Timer) pickSoundView() string {
 }
 
 func (t *Timer) settingsView() string {
-	if t.settings == soundView {
+	switch t.settings {
+	case soundView:
 		return t.pickSoundView()
+	case interruptionView:
+		return t.interruptionForm.View()
+	case tagsView:
+		return t.tagsForm.View()
 	}
 
 	return ""
 }
 
-func (t *Timer) helpView() string {
+// helpBindings returns the keys that apply to the current state of the timer.
+func (t *Timer) helpBindings() []key.Binding {
+	// only quitting applies while the first session is scheduled
+	if t.scheduled() {
+		return []key.Binding{defaultKeymap.quit}
+	}
+
 	if t.waitForNextSession {
-		return "\n" + t.help.ShortHelpView([]key.Binding{
+		if t.Opts.CLI.Flow && t.Current.Name == config.Work {
+			return []key.Binding{
+				defaultKeymap.enter,
+				defaultKeymap.quit,
+			}
+		}
+
+		return []key.Binding{
 			defaultKeymap.enter,
+			defaultKeymap.snooze,
 			defaultKeymap.quit,
-		})
+		}
+	}
+
+	if t.isFlow() {
+		return []key.Binding{
+			defaultKeymap.togglePlay,
+			defaultKeymap.finish,
+			defaultKeymap.display,
+			defaultKeymap.today,
+			defaultKeymap.tags,
+			defaultKeymap.sound,
+			defaultKeymap.internalInterrupt,
+			defaultKeymap.externalInterrupt,
+			defaultKeymap.quit,
+		}
 	}
 
 	if t.Current.Name == config.Work {
-		return "\n" + t.help.ShortHelpView([]key.Binding{
+		return []key.Binding{
 			defaultKeymap.togglePlay,
+			defaultKeymap.extend,
+			defaultKeymap.finishEarly,
+			defaultKeymap.display,
+			defaultKeymap.today,
+			defaultKeymap.tags,
 			defaultKeymap.sound,
+			defaultKeymap.internalInterrupt,
+			defaultKeymap.externalInterrupt,
 			defaultKeymap.quit,
-		})
+		}
 	}
 
-	return "\n" + t.help.ShortHelpView([]key.Binding{
+	return []key.Binding{
 		defaultKeymap.esc,
+		defaultKeymap.display,
+		defaultKeymap.today,
+		defaultKeymap.tags,
 		defaultKeymap.quit,
-	})
+	}
+}
+
+func (t *Timer) helpView() string {
+	return "\n" + t.help.ShortHelpView(t.helpBindings())
 }
 
+// View renders the timer along with a warning if a session couldn't be
+// saved.
 func (t *Timer) View() string {
+	// state changes are printed as they happen in accessible mode
+	if t.accessible() {
+		return ""
+	}
+
+	v := t.view()
+	if t.saveErr == nil {
+		return v
+	}
+
+	return v + defaultStyle.base.Render(
+		defaultStyle.error.Render(
+			fmt.Sprintf(
+				"⚠ The session could not be saved: %v. Retrying every %s.",
+				t.saveErr,
+				saveRetryInterval,
+			),
+		),
+	)
+}
+
+func (t *Timer) view() string {
+
+	if t.scheduled() {
+		return defaultStyle.base.Render(t.scheduledView())
+	}
+
+	if t.settings == shutdownView {
+		return defaultStyle.base.Render(t.summaryView())
+	}
+
 	if t.waitForNextSession {
 		return defaultStyle.base.Render(
 			t.sessionPromptView(),
@@ -163,15 +399,20 @@ func (t *Timer) View() string {
 		)
 	}
 
-	if t.clock.Timedout() || t.Current.Completed {
-		return ""
+	if t.settings == notesView {
+		return defaultStyle.base.Render(t.notesForm.View())
 	}
 
-	view := t.timerView()
+	if t.timedout() || t.Current.Completed {
+		return ""
+	}
 
+	// prompts are shown below the standard view regardless of the display mode
 	if t.settings != "" {
-		view += "\n\n" + t.settingsView()
+		return defaultStyle.base.Render(
+			t.standardView() + "\n\n" + t.settingsView(),
+		)
 	}
 
-	return defaultStyle.base.Render(view)
+	return t.timerView()
 }